@@ -0,0 +1,9 @@
+// Package api holds the published definitions of the service APIs.
+package api
+
+import _ "embed"
+
+// OpenAPI is the spec of the REST API, requests are validated against it.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
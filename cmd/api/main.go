@@ -6,13 +6,32 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
+	"github.com/penkovgd/closer"
+
+	"github.com/penkovgd/pr-reviews/api"
+	"github.com/penkovgd/pr-reviews/internal/adapters/auth"
+	"github.com/penkovgd/pr-reviews/internal/adapters/chat"
 	"github.com/penkovgd/pr-reviews/internal/adapters/db"
+	"github.com/penkovgd/pr-reviews/internal/adapters/email"
+	"github.com/penkovgd/pr-reviews/internal/adapters/forge"
+	"github.com/penkovgd/pr-reviews/internal/adapters/grpc"
+	"github.com/penkovgd/pr-reviews/internal/adapters/metrics"
+	"github.com/penkovgd/pr-reviews/internal/adapters/outbox"
+	"github.com/penkovgd/pr-reviews/internal/adapters/ratelimit"
 	"github.com/penkovgd/pr-reviews/internal/adapters/rest"
+	"github.com/penkovgd/pr-reviews/internal/adapters/schedule"
+	"github.com/penkovgd/pr-reviews/internal/adapters/stream"
+	"github.com/penkovgd/pr-reviews/internal/adapters/tracing"
+	"github.com/penkovgd/pr-reviews/internal/adapters/webhook"
 	"github.com/penkovgd/pr-reviews/internal/config"
 	"github.com/penkovgd/pr-reviews/internal/core"
 )
@@ -35,47 +54,248 @@ func run(cfg config.Config, log *slog.Logger) error {
 	log.Info("starting server")
 	log.Debug("debug messages are enabled")
 
+	// tracing of the HTTP API, the services and the database, closed last to export spans of the jobs
+	tracer, err := tracing.Setup(cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("set up tracing: %w", err)
+	}
+	defer closer.CloseOrLog(log, tracer)
+
+	// metrics of the HTTP API, the database and the review domain
+	m := metrics.New()
+
 	// database adapter
-	db, err := db.New(log, cfg.DBUrl)
+	db, err := db.New(log, cfg.DBUrl, m.ObserveQuery)
 	if err != nil {
 		return fmt.Errorf("connect to db: %w", err)
 	}
+	m.RegisterOpenReviews(log, db)
 	if err := db.Migrate(); err != nil {
 		return fmt.Errorf("migrate db: %w", err)
 	}
 
+	// chat notifier, an outbox sink and the digest job
+	notifier, err := chat.NewNotifier(log, db, db, cfg.Chat)
+	if err != nil {
+		return fmt.Errorf("create chat notifier: %w", err)
+	}
+
+	// outbox sinks
+	sinks := make(map[string]core.EventSink)
+	for _, name := range cfg.OutboxConfig.Sinks {
+		switch name {
+		case "webhook":
+			sinks[name] = webhook.NewSender(log, db, cfg.WebhookConfig)
+		case "log":
+			sinks[name] = outbox.NewLogSink(log)
+		case "chat":
+			sinks[name] = notifier
+		case "forge":
+			sinks[name] = forge.NewSink(log, db, db, forgeClients(log, cfg.Integrations))
+		case "file":
+			fileSink, err := outbox.NewFileSink(cfg.OutboxConfig.FilePath)
+			if err != nil {
+				return fmt.Errorf("create file sink: %w", err)
+			}
+			defer closer.CloseOrLog(log, fileSink)
+			sinks[name] = fileSink
+		default:
+			return fmt.Errorf("unknown outbox sink: %s", name)
+		}
+	}
+	dispatcher := outbox.NewDispatcher(log, db, sinks, cfg.OutboxConfig)
+
 	// services
-	teamService := core.NewTeamService(db, db)
-	userService := core.NewUserService(db, db)
-	prService := core.NewPullRequestService(db, db)
+	teamService := tracing.WrapTeamService(core.NewTeamService(db))
+	userService := tracing.WrapUserService(core.NewUserService(db, db, db))
+	reviewUpdates := stream.NewHub(cfg.HTTPConfig.StreamBuffer)
+	// events stored with PR changes are counted by the repository
+	prRepo := m.WrapPullRequestRepository(db)
+	prService := tracing.WrapPullRequestService(m.WrapPullRequestService(core.NewPullRequestService(prRepo, db, db, reviewUpdates)))
+	auditService := tracing.WrapAuditService(core.NewAuditService(db, db, db))
+	webhookService := tracing.WrapWebhookService(core.NewWebhookService(db, db, db))
+	integrationService := tracing.WrapIntegrationService(core.NewIntegrationService(db, db, db, prService))
+	chatService := tracing.WrapChatService(core.NewChatService(db, db, db))
+	if escalation := core.EscalationAction(cfg.StaleReviews.Escalation); !escalation.IsValid() {
+		return fmt.Errorf("unknown stale review escalation: %s", escalation)
+	}
+	staleReviewService := tracing.WrapStaleReviewService(core.NewStaleReviewService(prRepo, db, prService, core.ReviewSLA{
+		RemindAfter:   cfg.StaleReviews.RemindAfter,
+		EscalateAfter: cfg.StaleReviews.EscalateAfter,
+		Escalation:    core.EscalationAction(cfg.StaleReviews.Escalation),
+	}))
+
+	// email digest job, enabled by the SMTP host
+	var emailDigest *email.Digest
+	if cfg.Email.Host != "" {
+		emailDigest, err = email.NewDigest(log, db, userService, cfg.Email)
+		if err != nil {
+			return fmt.Errorf("create email digest: %w", err)
+		}
+	}
 
 	// rest adapter
 	mux := http.NewServeMux()
 	// Teams
 	mux.Handle("POST /team/add", rest.NewAddTeamHandler(log, teamService))
 	mux.Handle("GET /team/get", rest.NewGetTeamHandler(log, teamService))
+	mux.Handle("POST /team/setChatWebhook", rest.NewSetChatWebhookHandler(log, chatService))
 	// Users
 	mux.Handle("POST /users/setIsActive", rest.NewSetUserActiveHandler(log, userService))
 	mux.Handle("GET /users/getReview", rest.NewGetUserReviewHandler(log, userService))
+	mux.Handle("POST /users/setEmailDigest", rest.NewSetEmailDigestHandler(log, userService))
+	mux.Handle("GET /users/reviewStream", rest.NewReviewStreamHandler(log, userService, reviewUpdates))
 	// PullRequests
 	mux.Handle("POST /pullRequest/create", rest.NewCreatePRHandler(log, prService))
 	mux.Handle("POST /pullRequest/merge", rest.NewMergePRHandler(log, prService))
 	mux.Handle("POST /pullRequest/reassign", rest.NewReassignReviewerHandler(log, prService))
+	mux.Handle("POST /pullRequest/reviewers/add", rest.NewAddReviewerHandler(log, prService))
+	mux.Handle("POST /pullRequest/reviewers/remove", rest.NewRemoveReviewerHandler(log, prService))
+	mux.Handle("GET /pullRequest/history", rest.NewPRHistoryHandler(log, auditService))
+	// Audit
+	mux.Handle("GET /audit", rest.NewAuditHandler(log, auditService))
+	// Webhooks
+	mux.Handle("POST /webhooks/add", rest.NewAddWebhookHandler(log, webhookService))
+	mux.Handle("GET /webhooks/list", rest.NewListWebhooksHandler(log, webhookService))
+	mux.Handle("POST /webhooks/remove", rest.NewRemoveWebhookHandler(log, webhookService))
+	mux.Handle("GET /webhooks/deliveries", rest.NewListDeliveriesHandler(log, webhookService))
+	// Integrations
+	mux.Handle("POST /integrations/identities/add", rest.NewLinkIdentityHandler(log, integrationService))
+	if secret := cfg.Integrations.GitHub.WebhookSecret; secret != "" {
+		mux.Handle("POST /integrations/github/webhook", rest.NewGitHubWebhookHandler(log, secret, integrationService))
+	}
+	if token := cfg.Integrations.GitLab.WebhookToken; token != "" {
+		mux.Handle("POST /integrations/gitlab/webhook", rest.NewGitLabWebhookHandler(log, token, integrationService))
+	}
+	if secret := cfg.Integrations.Gitea.WebhookSecret; secret != "" {
+		mux.Handle("POST /integrations/gitea/webhook", rest.NewGiteaWebhookHandler(log, secret, integrationService))
+	}
 	// bonus: statistics
 	mux.Handle("GET /stats/user-assignments", rest.NewUserAssignmentStatsHandler(log, db))
+	// probes, readiness fails while the server drains after SIGTERM
+	var draining atomic.Bool
+	mux.Handle("GET /healthz", rest.NewLivenessHandler(log))
+	mux.Handle("GET /readyz", rest.NewReadinessHandler(log, db, draining.Load))
+	// API spec
+	mux.Handle("GET /openapi.yaml", rest.NewOpenAPIHandler(log, api.OpenAPI))
+	// Prometheus metrics
+	mux.Handle("GET /metrics", rest.NewMetricsHandler(m.Handler(log)))
+
+	withValidation, err := rest.WithValidation(api.OpenAPI)
+	if err != nil {
+		return fmt.Errorf("create request validation: %w", err)
+	}
+
+	withIdempotency := rest.WithIdempotency(log, db, cfg.Idempotency.TTL)
+	handler := withValidation(withIdempotency(mux))
+
+	// rate limiting, wrapped by authentication to tell clients by their tokens;
+	// probes and scrapes are exempt so a busy client cannot fail them
+	if cfg.RateLimit.Enabled {
+		policy, err := rateLimitPolicy(cfg.RateLimit)
+		if err != nil {
+			return fmt.Errorf("create rate limit policy: %w", err)
+		}
+		handler = rest.WithRateLimit(log, ratelimit.NewBuckets(), policy, "/healthz", "/readyz", "/metrics")(handler)
+	}
+
+	// tenant scoping, wrapped by authentication to bind credentials to their tenant
+	handler = rest.WithTenant(handler)
+
+	// authentication of both adapters, the APIs are open when it is disabled
+	var authn core.Authenticator
+	if cfg.Auth.Enabled {
+		authenticator, err := auth.NewAuthenticator(cfg.Auth)
+		if err != nil {
+			return fmt.Errorf("create authenticator: %w", err)
+		}
+		authn = authenticator
+		// the spec and probes are public, forge webhooks are verified by their own signature or token
+		handler = rest.WithAuth(log, authn, "/openapi.yaml", "/healthz", "/readyz", "/integrations/github/webhook", "/integrations/gitlab/webhook", "/integrations/gitea/webhook")(handler)
+	}
 
 	server := http.Server{
 		Addr:        cfg.HTTPConfig.Address,
 		ReadTimeout: cfg.HTTPConfig.Timeout,
-		Handler:     mux,
+		Handler:     tracing.WithTracing(mux)(m.WithHTTPMetrics(mux)(rest.WithBodyLimit(cfg.HTTPConfig.MaxBodySize)(rest.WithActor(handler)))),
+	}
+	// review streams are long-lived, end them for the shutdown to complete
+	server.RegisterOnShutdown(reviewUpdates.Close)
+
+	// grpc adapter
+	grpcServer := grpc.NewServer(log, authn, teamService, userService, prService, db)
+	grpcListener, err := net.Listen("tcp", cfg.GRPCConfig.Address)
+	if err != nil {
+		return fmt.Errorf("listen gRPC: %w", err)
 	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	grpcDone := make(chan struct{})
 	go func() {
+		defer close(grpcDone)
+		log.Info("running gRPC server", "address", cfg.GRPCConfig.Address)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Error("gRPC server failed", "error", err)
+			stop()
+		}
+	}()
+
+	// the dispatcher outlives the server to deliver events of the last requests
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() { dispatcher.Run(dispatcherCtx) })
+	if cfg.Chat.DigestAt != "" {
+		wg.Go(func() {
+			if err := notifier.RunDigests(dispatcherCtx); err != nil {
+				log.Error("chat digests stopped", "error", err)
+			}
+		})
+	}
+	if cfg.StaleReviews.Enabled {
+		staleLog := log.With("job", "stale reviews")
+		wg.Go(func() {
+			schedule.Every(dispatcherCtx, staleLog, cfg.StaleReviews.Interval, func(ctx context.Context) error {
+				report, err := staleReviewService.ProcessStaleReviews(ctx)
+				if report != (core.StaleReviewReport{}) {
+					staleLog.Info("stale reviews processed", "reminded", report.Reminded, "reassigned", report.Reassigned, "escalated", report.Escalated)
+				}
+				return err
+			})
+		})
+	}
+	idempotencyLog := log.With("job", "idempotency keys")
+	wg.Go(func() {
+		schedule.Every(dispatcherCtx, idempotencyLog, cfg.Idempotency.CleanupInterval, func(ctx context.Context) error {
+			deleted, err := db.DeleteExpiredIdempotencyKeys(ctx)
+			if deleted > 0 {
+				idempotencyLog.Debug("expired idempotency keys deleted", "count", deleted)
+			}
+			return err
+		})
+	})
+	if emailDigest != nil {
+		wg.Go(func() {
+			if err := emailDigest.Run(dispatcherCtx); err != nil {
+				log.Error("email digests stopped", "error", err)
+			}
+		})
+	}
+	defer func() {
+		stopDispatcher()
+		wg.Wait()
+	}()
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
+		log.Debug("draining server", "delay", cfg.HTTPConfig.DrainDelay)
+		draining.Store(true)
+		time.Sleep(cfg.HTTPConfig.DrainDelay)
 		log.Debug("shutting down server")
+		grpcServer.GracefulStop()
 		if err := server.Shutdown(context.Background()); err != nil {
 			log.Error("erroneous shutdown", "error", err)
 		}
@@ -87,9 +307,48 @@ func run(cfg config.Config, log *slog.Logger) error {
 			return fmt.Errorf("server closed unexpectedly: %w", err)
 		}
 	}
+	// wait for in-flight requests before stopping the dispatcher
+	<-shutdownDone
+	<-grpcDone
 	return nil
 }
 
+// forgeClients returns clients of the forges an API token is configured for.
+func forgeClients(log *slog.Logger, cfg config.IntegrationsConfig) map[core.Forge]core.ForgeClient {
+	clients := make(map[core.Forge]core.ForgeClient)
+	if cfg.GitHub.Token != "" {
+		clients[core.ForgeGitHub] = forge.NewGitHubClient(log, cfg.GitHub.APIURL, cfg.GitHub.Token, cfg.Timeout)
+	}
+	if cfg.Gitea.URL != "" && cfg.Gitea.Token != "" {
+		clients[core.ForgeGitea] = forge.NewGiteaClient(log, cfg.Gitea.URL, cfg.Gitea.Token, cfg.Timeout)
+	}
+	return clients
+}
+
+// rateLimitPolicy converts the limits of the config, every one must let some requests through.
+func rateLimitPolicy(cfg config.RateLimitConfig) (rest.RateLimitPolicy, error) {
+	policy := rest.RateLimitPolicy{
+		Default:        core.RateLimit{Rate: cfg.Default.RPS, Burst: cfg.Default.Burst},
+		ClientIPHeader: cfg.ClientIPHeader,
+	}
+	if policy.Default.Rate <= 0 || policy.Default.Burst < 1 {
+		return rest.RateLimitPolicy{}, errors.New("default limit must have positive rps and burst")
+	}
+	for _, group := range cfg.Groups {
+		limit := core.RateLimit{Rate: group.RPS, Burst: group.Burst}
+		if limit.Rate <= 0 || limit.Burst < 1 {
+			return rest.RateLimitPolicy{}, fmt.Errorf("limit of group %q must have positive rps and burst", group.Name)
+		}
+		policy.Groups = append(policy.Groups, rest.RateLimitGroup{
+			Name:         group.Name,
+			Methods:      group.Methods,
+			PathPrefixes: group.PathPrefixes,
+			Limit:        limit,
+		})
+	}
+	return policy, nil
+}
+
 func mustMakeLogger(logLevel string) *slog.Logger {
 	var level slog.Level
 	switch logLevel {
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/penkovgd/pr-reviews/api"
+)
+
+// registeredRoutes returns patterns of the mux.Handle calls in main.go, e.g. "POST /team/add".
+func registeredRoutes(t *testing.T) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "main.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse main.go: %v", err)
+	}
+
+	var routes []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Handle" {
+			return true
+		}
+		if ident, ok := sel.X.(*ast.Ident); !ok || ident.Name != "mux" {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			t.Errorf("route pattern at %v is not a string literal", lit)
+			return true
+		}
+		pattern, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			t.Fatalf("unquote %s: %v", lit.Value, err)
+		}
+		routes = append(routes, pattern)
+		return true
+	})
+	return routes
+}
+
+func TestRoutesAreDescribedInOpenAPI(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(api.OpenAPI)
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	if err := doc.Validate(t.Context()); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+
+	routes := registeredRoutes(t)
+	if len(routes) == 0 {
+		t.Fatal("no routes found in main.go")
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range routes {
+		registered[route] = true
+
+		method, path, ok := strings.Cut(route, " ")
+		if !ok {
+			t.Errorf("route %q has no method", route)
+			continue
+		}
+		if item := doc.Paths.Find(path); item == nil || item.GetOperation(method) == nil {
+			t.Errorf("route %q is not described in the spec", route)
+		}
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			if !registered[method+" "+path] {
+				t.Errorf("operation %s %s of the spec is not registered", method, path)
+			}
+		}
+	}
+}
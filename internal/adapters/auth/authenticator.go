@@ -0,0 +1,114 @@
+// Package auth resolves bearer tokens presented to the APIs.
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// Authenticator accepts static API tokens from the config and JWTs signed with the configured key.
+type Authenticator struct {
+	// tokens are keyed by digest, a lookup does not leak how much of a token matched
+	tokens map[[sha256.Size]byte]core.Principal
+	parser *jwt.Parser
+	key    any
+}
+
+type claims struct {
+	Role   string `json:"role"`
+	Tenant string `json:"tenant,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// tenantOf returns the tenant credentials are bound to. Only admins may omit it
+// to act in any tenant, other credentials without a tenant are bound to the default one.
+func tenantOf(role core.AccessRole, tenantID string) (string, error) {
+	switch {
+	case tenantID != "" && !core.IsValidTenantID(tenantID):
+		return "", fmt.Errorf("invalid tenant %q", tenantID)
+	case tenantID == "" && role != core.AccessAdmin:
+		return core.DefaultTenant, nil
+	}
+	return tenantID, nil
+}
+
+func NewAuthenticator(cfg config.AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{tokens: make(map[[sha256.Size]byte]core.Principal, len(cfg.Tokens))}
+	for i, token := range cfg.Tokens {
+		role := core.AccessRole(token.Role)
+		if token.Token == "" {
+			return nil, fmt.Errorf("API token %d is empty", i)
+		}
+		if !role.IsValid() {
+			return nil, fmt.Errorf("API token %d: unknown role %q", i, token.Role)
+		}
+		tenantID, err := tenantOf(role, token.Tenant)
+		if err != nil {
+			return nil, fmt.Errorf("API token %d: %w", i, err)
+		}
+		a.tokens[sha256.Sum256([]byte(token.Token))] = core.Principal{UserID: token.UserID, Role: role, TenantID: tenantID}
+	}
+
+	if cfg.JWT.Algorithm == "" {
+		return a, nil
+	}
+	keyData, err := os.ReadFile(cfg.JWT.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read JWT key: %w", err)
+	}
+	switch cfg.JWT.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		a.key = bytes.TrimSpace(keyData)
+	case jwt.SigningMethodRS256.Alg():
+		if a.key, err = jwt.ParseRSAPublicKeyFromPEM(keyData); err != nil {
+			return nil, fmt.Errorf("parse JWT key: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", cfg.JWT.Algorithm)
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods([]string{cfg.JWT.Algorithm}), jwt.WithExpirationRequired()}
+	if cfg.JWT.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWT.Issuer))
+	}
+	if cfg.JWT.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.JWT.Audience))
+	}
+	a.parser = jwt.NewParser(options...)
+	return a, nil
+}
+
+func (a *Authenticator) Authenticate(_ context.Context, token string) (core.Principal, error) {
+	if principal, ok := a.tokens[sha256.Sum256([]byte(token))]; ok {
+		return principal, nil
+	}
+	if a.parser == nil {
+		return core.Principal{}, core.ErrInvalidCredentials
+	}
+
+	var c claims
+	keyFunc := func(*jwt.Token) (any, error) { return a.key, nil }
+	if _, err := a.parser.ParseWithClaims(token, &c, keyFunc); err != nil {
+		return core.Principal{}, fmt.Errorf("%w: %w", core.ErrInvalidCredentials, err)
+	}
+	role := core.AccessRole(c.Role)
+	if !role.IsValid() {
+		return core.Principal{}, fmt.Errorf("%w: unknown role %q", core.ErrInvalidCredentials, c.Role)
+	}
+	if c.Subject == "" {
+		return core.Principal{}, fmt.Errorf("%w: sub claim is missing", core.ErrInvalidCredentials)
+	}
+	tenantID, err := tenantOf(role, c.Tenant)
+	if err != nil {
+		return core.Principal{}, fmt.Errorf("%w: %w", core.ErrInvalidCredentials, err)
+	}
+	return core.Principal{UserID: c.Subject, Role: role, TenantID: tenantID}, nil
+}
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+func writeKey(t *testing.T, data []byte) string {
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, c claims) string {
+	token, err := jwt.NewWithClaims(method, c).SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func validClaims(userID, role string) claims {
+	return claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+func TestAuthenticator_StaticTokens(t *testing.T) {
+	authn, err := NewAuthenticator(config.AuthConfig{Tokens: []config.APITokenConfig{
+		{Token: "admin-token", Role: "admin"},
+		{Token: "member-token", UserID: "u1", Role: "member"},
+		{Token: "acme-token", UserID: "u2", Role: "team-lead", Tenant: "acme"},
+	}})
+	if err != nil {
+		t.Fatalf("new authenticator: %v", err)
+	}
+
+	principal, err := authn.Authenticate(context.Background(), "member-token")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if principal != (core.Principal{UserID: "u1", Role: core.AccessMember, TenantID: core.DefaultTenant}) {
+		t.Errorf("principal = %+v", principal)
+	}
+	principal, err = authn.Authenticate(context.Background(), "acme-token")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if principal != (core.Principal{UserID: "u2", Role: core.AccessTeamLead, TenantID: "acme"}) {
+		t.Errorf("principal = %+v", principal)
+	}
+	principal, err = authn.Authenticate(context.Background(), "admin-token")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if principal != (core.Principal{Role: core.AccessAdmin}) {
+		t.Errorf("admin principal = %+v, want no tenant", principal)
+	}
+	if _, err := authn.Authenticate(context.Background(), "member-token2"); !errors.Is(err, core.ErrInvalidCredentials) {
+		t.Errorf("unknown token: err = %v, want ErrInvalidCredentials", err)
+	}
+
+	_, err = NewAuthenticator(config.AuthConfig{Tokens: []config.APITokenConfig{{Token: "t", Role: "owner"}}})
+	if err == nil {
+		t.Error("unknown role: want error")
+	}
+	_, err = NewAuthenticator(config.AuthConfig{Tokens: []config.APITokenConfig{{Token: "t", Role: "admin", Tenant: "Acme Inc"}}})
+	if err == nil {
+		t.Error("invalid tenant: want error")
+	}
+}
+
+func TestAuthenticator_HS256(t *testing.T) {
+	secret := []byte("jwt-secret")
+	authn, err := NewAuthenticator(config.AuthConfig{JWT: config.JWTConfig{
+		Algorithm: "HS256",
+		KeyFile:   writeKey(t, append(secret, '\n')),
+		Issuer:    "sso",
+	}})
+	if err != nil {
+		t.Fatalf("new authenticator: %v", err)
+	}
+
+	c := validClaims("u1", "team-lead")
+	c.Issuer = "sso"
+	c.Tenant = "acme"
+	principal, err := authn.Authenticate(context.Background(), sign(t, jwt.SigningMethodHS256, secret, c))
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if principal != (core.Principal{UserID: "u1", Role: core.AccessTeamLead, TenantID: "acme"}) {
+		t.Errorf("principal = %+v", principal)
+	}
+
+	expired := c
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noExpiry := c
+	noExpiry.ExpiresAt = nil
+	otherIssuer := c
+	otherIssuer.Issuer = "elsewhere"
+	unknownRole := c
+	unknownRole.Role = "owner"
+	noSubject := c
+	noSubject.Subject = ""
+	invalidTenant := c
+	invalidTenant.Tenant = "../acme"
+
+	invalid := map[string]string{
+		"expired":        sign(t, jwt.SigningMethodHS256, secret, expired),
+		"no expiry":      sign(t, jwt.SigningMethodHS256, secret, noExpiry),
+		"other issuer":   sign(t, jwt.SigningMethodHS256, secret, otherIssuer),
+		"unknown role":   sign(t, jwt.SigningMethodHS256, secret, unknownRole),
+		"no subject":     sign(t, jwt.SigningMethodHS256, secret, noSubject),
+		"invalid tenant": sign(t, jwt.SigningMethodHS256, secret, invalidTenant),
+		"wrong secret":   sign(t, jwt.SigningMethodHS256, []byte("other"), c),
+		"other method":   sign(t, jwt.SigningMethodHS384, secret, c),
+		"garbage":        "not a token",
+	}
+	for name, token := range invalid {
+		if _, err := authn.Authenticate(context.Background(), token); !errors.Is(err, core.ErrInvalidCredentials) {
+			t.Errorf("%s: err = %v, want ErrInvalidCredentials", name, err)
+		}
+	}
+}
+
+func TestAuthenticator_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	authn, err := NewAuthenticator(config.AuthConfig{JWT: config.JWTConfig{
+		Algorithm: "RS256",
+		KeyFile:   writeKey(t, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public})),
+	}})
+	if err != nil {
+		t.Fatalf("new authenticator: %v", err)
+	}
+
+	principal, err := authn.Authenticate(context.Background(), sign(t, jwt.SigningMethodRS256, key, validClaims("bot-ci", "bot")))
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if principal != (core.Principal{UserID: "bot-ci", Role: core.AccessBot, TenantID: core.DefaultTenant}) {
+		t.Errorf("principal = %+v", principal)
+	}
+
+	// a token signed with the public key as an HMAC secret must not pass
+	forged := sign(t, jwt.SigningMethodHS256, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}), validClaims("u1", "admin"))
+	if _, err := authn.Authenticate(context.Background(), forged); !errors.Is(err, core.ErrInvalidCredentials) {
+		t.Errorf("forged token: err = %v, want ErrInvalidCredentials", err)
+	}
+}
@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/schedule"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// RunDigests posts digests every day at cfg.DigestAt local time until ctx is done.
+func (n *Notifier) RunDigests(ctx context.Context) error {
+	return schedule.Daily(ctx, n.log.With("job", "chat digest"), n.cfg.DigestAt, n.SendDigests)
+}
+
+// SendDigests posts open PRs of each team with a chat in every tenant, teams without open PRs are skipped.
+func (n *Notifier) SendDigests(ctx context.Context) error {
+	tenants, err := n.chatRepo.GetChatWebhookTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("get chat webhook tenants: %w", err)
+	}
+	if len(n.cfg.Webhooks) > 0 && !slices.Contains(tenants, core.DefaultTenant) {
+		tenants = append(tenants, core.DefaultTenant)
+	}
+
+	var errs []error
+	for _, tenantID := range tenants {
+		if err := n.sendTenantDigests(core.WithTenant(ctx, tenantID)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenantID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) sendTenantDigests(ctx context.Context) error {
+	webhooks, err := n.webhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var errs []error
+	for team, url := range webhooks {
+		prs, err := n.prRepo.GetOpenPRsByTeam(ctx, team)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("team %s: %w", team, err))
+			continue
+		}
+		if len(prs) == 0 {
+			continue
+		}
+
+		msg := DigestMessage{TeamName: team, PRs: make([]DigestPR, len(prs))}
+		for i, pr := range prs {
+			msg.PRs[i] = DigestPR{PullRequest: pr}
+			if pr.CreatedAt != nil {
+				msg.PRs[i].Age = now.Sub(*pr.CreatedAt)
+			}
+		}
+
+		var text bytes.Buffer
+		if err := n.templates[digestTemplate].Execute(&text, msg); err != nil {
+			errs = append(errs, fmt.Errorf("render digest of team %s: %w", team, err))
+			continue
+		}
+		if err := n.post(ctx, url, text.String()); err != nil {
+			errs = append(errs, fmt.Errorf("post digest of team %s: %w", team, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,122 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"text/template"
+
+	"github.com/penkovgd/closer"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// Notifier posts messages to Slack or Mattermost compatible incoming webhooks of team chats.
+// It is an outbox sink for event messages and runs daily digests.
+type Notifier struct {
+	log       *slog.Logger
+	chatRepo  core.ChatRepository
+	prRepo    core.PullRequestRepository
+	client    *http.Client
+	cfg       config.ChatConfig
+	templates map[string]*template.Template
+}
+
+func NewNotifier(log *slog.Logger, chatRepo core.ChatRepository, prRepo core.PullRequestRepository, cfg config.ChatConfig) (*Notifier, error) {
+	templates, err := parseTemplates(cfg.Templates)
+	if err != nil {
+		return nil, err
+	}
+	return &Notifier{
+		log:       log,
+		chatRepo:  chatRepo,
+		prRepo:    prRepo,
+		client:    &http.Client{Timeout: cfg.Timeout},
+		cfg:       cfg,
+		templates: templates,
+	}, nil
+}
+
+// Send posts a message about the event to its team chat, events without a template are skipped.
+func (n *Notifier) Send(ctx context.Context, event core.Event) error {
+	tmpl, ok := n.templates[string(event.Type)]
+	if !ok || event.TeamName == "" {
+		return nil
+	}
+
+	url, err := n.webhookURL(ctx, event.TeamName)
+	if err != nil || url == "" {
+		return err
+	}
+
+	msg := EventMessage{Event: event}
+	if pr, err := n.prRepo.GetPRByID(ctx, event.PRID); err == nil {
+		msg.PRName = pr.Name
+	}
+
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, msg); err != nil {
+		return fmt.Errorf("render %s message: %w", event.Type, err)
+	}
+	return n.post(ctx, url, text.String())
+}
+
+// webhooks returns URLs by team name of the tenant of ctx, set through the API or in the config.
+// Webhooks in the config belong to the default tenant.
+func (n *Notifier) webhooks(ctx context.Context) (map[string]string, error) {
+	stored, err := n.chatRepo.GetChatWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chat webhooks: %w", err)
+	}
+
+	webhooks := make(map[string]string, len(n.cfg.Webhooks)+len(stored))
+	if core.TenantFromContext(ctx) == core.DefaultTenant {
+		for team, url := range n.cfg.Webhooks {
+			webhooks[team] = url
+		}
+	}
+	for team, url := range stored {
+		webhooks[team] = url
+	}
+	return webhooks, nil
+}
+
+func (n *Notifier) webhookURL(ctx context.Context, teamName string) (string, error) {
+	webhooks, err := n.webhooks(ctx)
+	if err != nil {
+		return "", err
+	}
+	return webhooks[teamName], nil
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+func (n *Notifier) post(ctx context.Context, url, text string) error {
+	body, err := json.Marshal(message{Text: text})
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer closer.CloseOrLog(n.log, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
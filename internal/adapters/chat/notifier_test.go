@@ -0,0 +1,136 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type fakeChatRepo struct {
+	core.ChatRepository
+	webhooks map[string]string
+}
+
+func (r *fakeChatRepo) GetChatWebhooks(context.Context) (map[string]string, error) {
+	return r.webhooks, nil
+}
+
+func (r *fakeChatRepo) GetChatWebhookTenants(context.Context) ([]string, error) {
+	if len(r.webhooks) == 0 {
+		return nil, nil
+	}
+	return []string{core.DefaultTenant}, nil
+}
+
+type fakePRRepo struct {
+	core.PullRequestRepository
+	prs []*core.PullRequest
+}
+
+func (r *fakePRRepo) GetPRByID(_ context.Context, prID string) (*core.PullRequest, error) {
+	for _, pr := range r.prs {
+		if pr.ID == prID {
+			return pr, nil
+		}
+	}
+	return nil, core.ErrPRNotFound
+}
+
+func (r *fakePRRepo) GetOpenPRsByTeam(context.Context, string) ([]*core.PullRequest, error) {
+	return r.prs, nil
+}
+
+// newReceiver returns a chat webhook URL, texts of posted messages are sent to the channel.
+func newReceiver(t *testing.T) (string, <-chan string) {
+	texts := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("decode message: %v", err)
+		}
+		texts <- msg.Text
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, texts
+}
+
+func TestNotifier_SendRendersTemplates(t *testing.T) {
+	staticURL, staticTexts := newReceiver(t)
+	storedURL, storedTexts := newReceiver(t)
+
+	cfg := config.ChatConfig{
+		Timeout:   time.Second,
+		Webhooks:  map[string]string{"backend": staticURL, "frontend": staticURL},
+		Templates: map[string]string{string(core.EventPRMerged): "merged {{.PRName}} by {{.ActorID}}"},
+	}
+	// the URL set through the API takes precedence over the config
+	chatRepo := &fakeChatRepo{webhooks: map[string]string{"frontend": storedURL}}
+	prRepo := &fakePRRepo{prs: []*core.PullRequest{{ID: "pr-1", Name: "Add search"}}}
+	notifier, err := NewNotifier(slog.Default(), chatRepo, prRepo, cfg)
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+
+	events := []core.Event{
+		{Type: core.EventReviewerAssigned, PRID: "pr-1", TeamName: "backend", UserID: "u1"},
+		{Type: core.EventPRMerged, PRID: "pr-1", TeamName: "frontend", ActorID: "u2"},
+		// no template
+		{Type: core.EventTeamCreated, TeamName: "backend"},
+		// no chat
+		{Type: core.EventPRMerged, PRID: "pr-1", TeamName: "mobile"},
+	}
+	for _, e := range events {
+		if err := notifier.Send(context.Background(), e); err != nil {
+			t.Fatalf("send %s: %v", e.Type, err)
+		}
+	}
+
+	if got, want := <-staticTexts, `u1 was assigned to review "Add search" (pr-1)`; got != want {
+		t.Errorf("assigned message = %q, want %q", got, want)
+	}
+	if got, want := <-storedTexts, "merged Add search by u2"; got != want {
+		t.Errorf("merged message = %q, want %q", got, want)
+	}
+	if len(staticTexts)+len(storedTexts) != 0 {
+		t.Error("unexpected messages posted")
+	}
+}
+
+func TestNotifier_SendDigests(t *testing.T) {
+	url, texts := newReceiver(t)
+	created := time.Now().Add(-50 * time.Hour)
+	prRepo := &fakePRRepo{prs: []*core.PullRequest{
+		{ID: "pr-1", Name: "Add search", AuthorID: "u1", CreatedAt: &created, AssignedReviewers: []string{"u2", "u3"}},
+	}}
+	cfg := config.ChatConfig{Timeout: time.Second, Webhooks: map[string]string{"backend": url}}
+	notifier, err := NewNotifier(slog.Default(), &fakeChatRepo{}, prRepo, cfg)
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+
+	if err := notifier.SendDigests(context.Background()); err != nil {
+		t.Fatalf("send digests: %v", err)
+	}
+
+	text := <-texts
+	for _, want := range []string{"Open pull requests of backend: 1", `"Add search" (pr-1) by u1, open for 2d, reviewers: u2, u3`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("digest %q does not contain %q", text, want)
+		}
+	}
+}
+
+func TestNewNotifier_RejectsUnknownTemplate(t *testing.T) {
+	cfg := config.ChatConfig{Templates: map[string]string{"pr.closed": "closed"}}
+	if _, err := NewNotifier(slog.Default(), &fakeChatRepo{}, &fakePRRepo{}, cfg); err == nil {
+		t.Fatal("unknown template must be rejected")
+	}
+}
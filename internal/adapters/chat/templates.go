@@ -0,0 +1,71 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/textfmt"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// digestTemplate is the key of the digest template in config.ChatConfig.Templates.
+const digestTemplate = "digest"
+
+var defaultTemplates = map[string]string{
+	string(core.EventReviewerAssigned):   `{{.UserID}} was assigned to review "{{.PRName}}" ({{.PRID}})`,
+	string(core.EventReviewerReassigned): `{{.UserID}} replaced {{.OldUserID}} as a reviewer of "{{.PRName}}" ({{.PRID}})`,
+	string(core.EventPRMerged):           `"{{.PRName}}" ({{.PRID}}) was merged`,
+	string(core.EventReviewerReminded):   `{{.UserID}}, "{{.PRName}}" ({{.PRID}}) is still waiting for your review`,
+	string(core.EventReviewerEscalated):  `{{with .UserID}}{{.}}, {{end}}review of "{{.PRName}}" ({{.PRID}}) by {{.OldUserID}} is overdue`,
+	digestTemplate: `Open pull requests of {{.TeamName}}: {{len .PRs}}
+{{range .PRs}}• "{{.Name}}" ({{.ID}}) by {{.AuthorID}}, open for {{age .Age}}, reviewers: {{join .AssignedReviewers ", "}}
+{{end}}`,
+}
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"age":  textfmt.Age,
+}
+
+// EventMessage is the data of event templates.
+type EventMessage struct {
+	core.Event
+	PRName string
+}
+
+// DigestMessage is the data of the digest template.
+type DigestMessage struct {
+	TeamName string
+	PRs      []DigestPR
+}
+
+type DigestPR struct {
+	*core.PullRequest
+	Age time.Duration
+}
+
+// parseTemplates parses the defaults overridden by custom templates.
+func parseTemplates(custom map[string]string) (map[string]*template.Template, error) {
+	sources := make(map[string]string, len(defaultTemplates))
+	for name, text := range defaultTemplates {
+		sources[name] = text
+	}
+	for name, text := range custom {
+		if _, ok := defaultTemplates[name]; !ok {
+			return nil, fmt.Errorf("unknown template %q", name)
+		}
+		sources[name] = text
+	}
+
+	templates := make(map[string]*template.Template, len(sources))
+	for name, text := range sources {
+		tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %q: %w", name, err)
+		}
+		templates[name] = tmpl
+	}
+	return templates, nil
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+func (d *DB) SetChatWebhook(ctx context.Context, teamName, url string) error {
+	query := `INSERT INTO team_chat_webhooks (team_name, url, tenant_id) VALUES ($1, $2, $3)
+	ON CONFLICT (tenant_id, team_name) DO UPDATE SET url = EXCLUDED.url`
+	if _, err := d.conn.ExecContext(ctx, query, teamName, url, core.TenantFromContext(ctx)); err != nil {
+		return fmt.Errorf("set chat webhook of team %s: %w", teamName, err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteChatWebhook(ctx context.Context, teamName string) error {
+	query := `DELETE FROM team_chat_webhooks WHERE team_name = $1 AND tenant_id = $2`
+	if _, err := d.conn.ExecContext(ctx, query, teamName, core.TenantFromContext(ctx)); err != nil {
+		return fmt.Errorf("delete chat webhook of team %s: %w", teamName, err)
+	}
+	return nil
+}
+
+func (d *DB) GetChatWebhooks(ctx context.Context) (map[string]string, error) {
+	var rows []struct {
+		TeamName string `db:"team_name"`
+		URL      string `db:"url"`
+	}
+	query := `SELECT team_name, url FROM team_chat_webhooks WHERE tenant_id = $1`
+	if err := d.conn.SelectContext(ctx, &rows, query, core.TenantFromContext(ctx)); err != nil {
+		return nil, fmt.Errorf("get chat webhooks: %w", err)
+	}
+
+	webhooks := make(map[string]string, len(rows))
+	for _, row := range rows {
+		webhooks[row.TeamName] = row.URL
+	}
+	return webhooks, nil
+}
+
+func (d *DB) GetChatWebhookTenants(ctx context.Context) ([]string, error) {
+	var tenants []string
+	query := `SELECT DISTINCT tenant_id FROM team_chat_webhooks ORDER BY tenant_id`
+	if err := d.conn.SelectContext(ctx, &tenants, query); err != nil {
+		return nil, fmt.Errorf("get chat webhook tenants: %w", err)
+	}
+	return tenants, nil
+}
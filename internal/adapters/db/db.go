@@ -1,10 +1,13 @@
 package db
 
 import (
+	"fmt"
 	"log/slog"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
+	"github.com/penkovgd/closer"
 )
 
 type DB struct {
@@ -12,10 +15,19 @@ type DB struct {
 	conn *sqlx.DB
 }
 
-func New(log *slog.Logger, address string) (*DB, error) {
-	db, err := sqlx.Connect("pgx", address)
+// New connects to the database. Statements are traced as spans of the global tracer provider
+// and reported to observe unless it is nil.
+func New(log *slog.Logger, address string, observe QueryObserver) (*DB, error) {
+	connConfig, err := pgx.ParseConfig(address)
 	if err != nil {
+		return nil, fmt.Errorf("parse address: %w", err)
+	}
+	connConfig.Tracer = queryTracer{observe: observe}
+
+	db := sqlx.NewDb(stdlib.OpenDB(*connConfig), "pgx")
+	if err := db.Ping(); err != nil {
 		log.Error("connection problem", "address", address, "error", err)
+		closer.CloseOrLog(log, db)
 		return nil, err
 	}
 
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// eventColumns selects pr_events aliased as e.
+const eventColumns = `e.id, e.type,
+	COALESCE(e.pull_request_id, '') AS pull_request_id,
+	COALESCE(e.team_name, '') AS team_name,
+	COALESCE(e.user_id, '') AS user_id,
+	COALESCE(e.old_user_id, '') AS old_user_id,
+	COALESCE(e.actor_id, '') AS actor_id,
+	e.created_at, e.tenant_id`
+
+// insertEvents appends events and queues them in the outbox within the given transaction,
+// so events are published if and only if the change producing them is committed.
+// Generated IDs are filled in the events, events without a tenant belong to the tenant of ctx.
+func insertEvents(ctx context.Context, tx *sqlx.Tx, events []core.Event) error {
+	query := `
+		INSERT INTO pr_events (type, pull_request_id, team_name, user_id, old_user_id, actor_id, created_at, tenant_id)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7, $8)
+		RETURNING id
+	`
+	outboxQuery := `INSERT INTO outbox (event_id) VALUES ($1)`
+	for i := range events {
+		e := &events[i]
+		if e.TenantID == "" {
+			e.TenantID = core.TenantFromContext(ctx)
+		}
+		err := tx.QueryRowxContext(ctx, query, e.Type, e.PRID, e.TeamName, e.UserID, e.OldUserID, e.ActorID, e.CreatedAt, e.TenantID).Scan(&e.ID)
+		if err != nil {
+			return fmt.Errorf("insert event %s: %w", e.Type, err)
+		}
+		if _, err := tx.ExecContext(ctx, outboxQuery, e.ID); err != nil {
+			return fmt.Errorf("queue event %d: %w", e.ID, err)
+		}
+	}
+	return nil
+}
+
+func (d *DB) GetPREvents(ctx context.Context, prID string) ([]core.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM pr_events e WHERE e.pull_request_id = $1 AND e.tenant_id = $2 ORDER BY e.id`
+
+	var events []core.Event
+	if err := d.conn.SelectContext(ctx, &events, query, prID, core.TenantFromContext(ctx)); err != nil {
+		return nil, fmt.Errorf("get events of PR %s: %w", prID, err)
+	}
+	return events, nil
+}
+
+func (d *DB) ListEvents(ctx context.Context, filter core.EventFilter) ([]core.Event, error) {
+	var conditions []string
+	var args []any
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	where("e.tenant_id = $%d", core.TenantFromContext(ctx))
+	if filter.Type != "" {
+		where("e.type = $%d", filter.Type)
+	}
+	if filter.PRID != "" {
+		where("e.pull_request_id = $%d", filter.PRID)
+	}
+	if filter.TeamName != "" {
+		where("e.team_name = $%d", filter.TeamName)
+	}
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("(e.user_id = $%[1]d OR e.old_user_id = $%[1]d)", len(args)))
+	}
+	if filter.ActorID != "" {
+		where("e.actor_id = $%d", filter.ActorID)
+	}
+	if filter.Since != nil {
+		where("e.created_at >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		where("e.created_at < $%d", *filter.Until)
+	}
+
+	query := `SELECT ` + eventColumns + ` FROM pr_events e WHERE ` + strings.Join(conditions, " AND ")
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(` ORDER BY e.id DESC LIMIT $%d`, len(args))
+
+	var events []core.Event
+	if err := d.conn.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	return events, nil
+}
+
+func (d *DB) GetReviewEvents(ctx context.Context, userID string, afterID int64, limit int) ([]core.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM pr_events e
+	WHERE e.id > $2 AND e.tenant_id = $4 AND (
+		(e.type IN ('reviewer.assigned', 'reviewer.reassigned', 'reviewer.removed') AND (e.user_id = $1 OR e.old_user_id = $1))
+		OR (e.type = 'pr.merged' AND EXISTS (
+			SELECT 1 FROM pull_request_reviewers prr
+			WHERE prr.tenant_id = e.tenant_id AND prr.pull_request_id = e.pull_request_id AND prr.user_id = $1
+		))
+	)
+	ORDER BY e.id LIMIT $3`
+
+	var events []core.Event
+	if err := d.conn.SelectContext(ctx, &events, query, userID, afterID, limit, core.TenantFromContext(ctx)); err != nil {
+		return nil, fmt.Errorf("get review events of user %s: %w", userID, err)
+	}
+	return events, nil
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/golang-migrate/migrate/v4/database/pgx"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// Ping checks that a connection to the database can be made.
+func (db *DB) Ping(ctx context.Context) error {
+	return db.conn.PingContext(ctx)
+}
+
+// MigrationStatus reads the version golang-migrate recorded in its table
+// and compares it to the latest embedded migration.
+func (db *DB) MigrationStatus(ctx context.Context) (core.MigrationStatus, error) {
+	latest, err := latestMigration()
+	if err != nil {
+		return core.MigrationStatus{}, fmt.Errorf("read migrations: %w", err)
+	}
+	status := core.MigrationStatus{Latest: latest}
+	err = db.conn.QueryRowContext(ctx, `SELECT version, dirty FROM `+pgx.DefaultMigrationsTable+` LIMIT 1`).
+		Scan(&status.Version, &status.Dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return core.MigrationStatus{}, err
+	}
+	return status, nil
+}
+
+// latestMigration is the version of the last embedded migration, the files do not change at runtime.
+var latestMigration = sync.OnceValues(func() (uint, error) {
+	source, err := iofs.New(migrationFiles, "migrations")
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+})
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+func (d *DB) ReserveIdempotencyKey(ctx context.Context, key core.IdempotencyKey, requestHash string, ttl time.Duration) (*core.IdempotentResponse, bool, error) {
+	tenantID := core.TenantFromContext(ctx)
+	// an expired key is claimed as if it was never used, it may not be purged yet
+	query := `
+		INSERT INTO idempotency_keys (key, route, request_hash, expires_at, tenant_id, client)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4), $5, $6)
+		ON CONFLICT (tenant_id, client, key, route) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status = 0, content_type = '', body = '',
+			expires_at = EXCLUDED.expires_at, created_at = CURRENT_TIMESTAMP
+		WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+	`
+	result, err := d.conn.ExecContext(ctx, query, key.Key, key.Route, requestHash, ttl.Seconds(), tenantID, key.Client)
+	if err != nil {
+		return nil, false, fmt.Errorf("reserve idempotency key %q of %s: %w", key.Key, key.Route, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("get rows affected for idempotency key %q of %s: %w", key.Key, key.Route, err)
+	}
+	if rowsAffected > 0 {
+		return nil, true, nil
+	}
+
+	var response core.IdempotentResponse
+	query = `SELECT request_hash, status, content_type, body FROM idempotency_keys
+	WHERE key = $1 AND route = $2 AND tenant_id = $3 AND client = $4`
+	if err := d.conn.GetContext(ctx, &response, query, key.Key, key.Route, tenantID, key.Client); err != nil {
+		return nil, false, fmt.Errorf("get response of idempotency key %q of %s: %w", key.Key, key.Route, err)
+	}
+	return &response, false, nil
+}
+
+func (d *DB) SaveIdempotentResponse(ctx context.Context, key core.IdempotencyKey, response *core.IdempotentResponse) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = $3, content_type = $4, body = $5
+		WHERE key = $1 AND route = $2 AND tenant_id = $6 AND client = $7
+	`
+	_, err := d.conn.ExecContext(ctx, query, key.Key, key.Route, response.Status, response.ContentType, response.Body, core.TenantFromContext(ctx), key.Client)
+	if err != nil {
+		return fmt.Errorf("save response of idempotency key %q of %s: %w", key.Key, key.Route, err)
+	}
+	return nil
+}
+
+func (d *DB) ReleaseIdempotencyKey(ctx context.Context, key core.IdempotencyKey) error {
+	query := `DELETE FROM idempotency_keys WHERE key = $1 AND route = $2 AND tenant_id = $3 AND client = $4`
+	if _, err := d.conn.ExecContext(ctx, query, key.Key, key.Route, core.TenantFromContext(ctx), key.Client); err != nil {
+		return fmt.Errorf("release idempotency key %q of %s: %w", key.Key, key.Route, err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= CURRENT_TIMESTAMP`
+	result, err := d.conn.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected for expired idempotency keys: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+func (d *DB) UpsertIdentity(ctx context.Context, identity *core.ForgeIdentity) error {
+	query := `INSERT INTO forge_identities (forge, login, user_id, tenant_id) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (tenant_id, forge, login) DO UPDATE SET user_id = EXCLUDED.user_id`
+	if _, err := d.conn.ExecContext(ctx, query, identity.Forge, identity.Login, identity.UserID, core.TenantFromContext(ctx)); err != nil {
+		return fmt.Errorf("upsert %s identity %s: %w", identity.Forge, identity.Login, err)
+	}
+	return nil
+}
+
+func (d *DB) GetIdentityByLogin(ctx context.Context, forge core.Forge, login string) (*core.ForgeIdentity, error) {
+	var identity core.ForgeIdentity
+	query := `SELECT forge, login, user_id FROM forge_identities WHERE forge = $1 AND login = $2 AND tenant_id = $3`
+	if err := d.conn.GetContext(ctx, &identity, query, forge, login, core.TenantFromContext(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s login %s: %w", forge, login, core.ErrIdentityNotFound)
+		}
+		return nil, fmt.Errorf("get %s identity %s: %w", forge, login, err)
+	}
+	return &identity, nil
+}
+
+// GetIdentityByUser returns the first login of the user if there are several.
+func (d *DB) GetIdentityByUser(ctx context.Context, forge core.Forge, userID string) (*core.ForgeIdentity, error) {
+	var identity core.ForgeIdentity
+	query := `SELECT forge, login, user_id FROM forge_identities
+	WHERE forge = $1 AND user_id = $2 AND tenant_id = $3 ORDER BY login LIMIT 1`
+	if err := d.conn.GetContext(ctx, &identity, query, forge, userID, core.TenantFromContext(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s identity of user %s: %w", forge, userID, core.ErrIdentityNotFound)
+		}
+		return nil, fmt.Errorf("get %s identity of user %s: %w", forge, userID, err)
+	}
+	return &identity, nil
+}
+
+func (d *DB) GetPRSource(ctx context.Context, prID string) (*core.ForgePullRequest, error) {
+	var source core.ForgePullRequest
+	query := `SELECT forge, repository, number FROM pull_request_sources WHERE pull_request_id = $1 AND tenant_id = $2`
+	if err := d.conn.GetContext(ctx, &source, query, prID, core.TenantFromContext(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("source of PR %s: %w", prID, core.ErrPRSourceNotFound)
+		}
+		return nil, fmt.Errorf("get source of PR %s: %w", prID, err)
+	}
+	return &source, nil
+}
@@ -0,0 +1,5 @@
+CREATE TYPE user_role AS ENUM ('junior', 'middle', 'senior', 'lead');
+ALTER TABLE users ADD COLUMN role user_role NOT NULL DEFAULT 'middle';
+ALTER TABLE teams
+    ADD COLUMN required_role user_role NOT NULL DEFAULT 'senior',
+    ADD COLUMN required_count INTEGER NOT NULL DEFAULT 0 CHECK (required_count >= 0);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS assigned_by;
+ALTER TABLE teams DROP COLUMN IF EXISTS max_reviewers;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE teams ADD COLUMN max_reviewers INTEGER NOT NULL DEFAULT 3 CHECK (max_reviewers > 0);
+ALTER TABLE pull_request_reviewers ADD COLUMN assigned_by VARCHAR(255) REFERENCES users(id);
\ No newline at end of file
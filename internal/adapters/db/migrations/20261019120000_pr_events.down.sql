@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trg_pr_events_append_only ON pr_events;
+DROP FUNCTION IF EXISTS pr_events_append_only;
+DROP INDEX IF EXISTS idx_pr_events_created_at;
+DROP INDEX IF EXISTS idx_pr_events_user_id;
+DROP INDEX IF EXISTS idx_pr_events_team_name;
+DROP INDEX IF EXISTS idx_pr_events_pull_request_id;
+DROP TABLE IF EXISTS pr_events;
\ No newline at end of file
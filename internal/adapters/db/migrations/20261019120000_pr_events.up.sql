@@ -0,0 +1,39 @@
+CREATE TABLE pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(64) NOT NULL,
+    pull_request_id VARCHAR(255),
+    team_name VARCHAR(255),
+    user_id VARCHAR(255),
+    old_user_id VARCHAR(255),
+    actor_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_pr_events_pull_request_id ON pr_events(pull_request_id);
+CREATE INDEX idx_pr_events_team_name ON pr_events(team_name);
+CREATE INDEX idx_pr_events_user_id ON pr_events(user_id);
+CREATE INDEX idx_pr_events_created_at ON pr_events(created_at);
+
+CREATE FUNCTION pr_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pr_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER trg_pr_events_append_only
+    BEFORE UPDATE OR DELETE ON pr_events
+    FOR EACH ROW EXECUTE FUNCTION pr_events_append_only();
+
+-- history of data created before the audit log existed
+INSERT INTO pr_events (type, pull_request_id, team_name, user_id, actor_id, created_at)
+SELECT 'pr.created', pr.id, u.team_name, pr.author_id, pr.author_id, COALESCE(pr.created_at, CURRENT_TIMESTAMP)
+FROM pull_requests pr
+JOIN users u ON u.id = pr.author_id;
+INSERT INTO pr_events (type, pull_request_id, team_name, user_id, actor_id, created_at)
+SELECT 'reviewer.assigned', prr.pull_request_id, u.team_name, prr.user_id, prr.assigned_by, COALESCE(prr.assigned_at, CURRENT_TIMESTAMP)
+FROM pull_request_reviewers prr
+JOIN pull_requests pr ON pr.id = prr.pull_request_id
+JOIN users u ON u.id = pr.author_id;
+INSERT INTO pr_events (type, pull_request_id, team_name, created_at)
+SELECT 'pr.merged', pr.id, u.team_name, pr.merged_at
+FROM pull_requests pr
+JOIN users u ON u.id = pr.author_id
+WHERE pr.status = 'MERGED' AND pr.merged_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_forge_identities_user_id;
+DROP TABLE IF EXISTS pull_request_sources;
+DROP TABLE IF EXISTS forge_identities;
\ No newline at end of file
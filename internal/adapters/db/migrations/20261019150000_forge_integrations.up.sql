@@ -0,0 +1,13 @@
+CREATE TABLE forge_identities (
+    forge VARCHAR(32) NOT NULL,
+    login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (forge, login)
+);
+CREATE TABLE pull_request_sources (
+    pull_request_id VARCHAR(255) PRIMARY KEY REFERENCES pull_requests(id) ON DELETE CASCADE,
+    forge VARCHAR(32) NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    number INTEGER NOT NULL
+);
+CREATE INDEX idx_forge_identities_user_id ON forge_identities(user_id);
\ No newline at end of file
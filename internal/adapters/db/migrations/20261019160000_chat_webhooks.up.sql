@@ -0,0 +1,4 @@
+CREATE TABLE team_chat_webhooks (
+    team_name VARCHAR(255) PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE,
+    url TEXT NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_digest,
+    DROP COLUMN IF EXISTS email;
\ No newline at end of file
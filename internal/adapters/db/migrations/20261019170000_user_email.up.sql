@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN email VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN email_digest BOOLEAN NOT NULL DEFAULT TRUE;
\ No newline at end of file
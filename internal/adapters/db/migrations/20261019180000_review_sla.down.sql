@@ -0,0 +1,7 @@
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS escalated_at,
+    DROP COLUMN IF EXISTS reminded_at;
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS sla_escalation,
+    DROP COLUMN IF EXISTS sla_escalate_after_seconds,
+    DROP COLUMN IF EXISTS sla_remind_after_seconds;
\ No newline at end of file
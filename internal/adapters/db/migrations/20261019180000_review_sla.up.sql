@@ -0,0 +1,7 @@
+ALTER TABLE teams
+    ADD COLUMN sla_remind_after_seconds BIGINT NOT NULL DEFAULT 0 CHECK (sla_remind_after_seconds >= 0),
+    ADD COLUMN sla_escalate_after_seconds BIGINT NOT NULL DEFAULT 0 CHECK (sla_escalate_after_seconds >= 0),
+    ADD COLUMN sla_escalation VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN reminded_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN escalated_at TIMESTAMP WITH TIME ZONE;
\ No newline at end of file
@@ -0,0 +1,53 @@
+DROP INDEX idx_webhooks_tenant_id;
+DROP INDEX idx_pr_events_tenant_id;
+
+ALTER TABLE users DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_author_id_fkey;
+ALTER TABLE pull_request_reviewers
+    DROP CONSTRAINT pull_request_reviewers_pull_request_id_fkey,
+    DROP CONSTRAINT pull_request_reviewers_user_id_fkey,
+    DROP CONSTRAINT pull_request_reviewers_assigned_by_fkey;
+ALTER TABLE webhooks DROP CONSTRAINT webhooks_team_name_fkey;
+ALTER TABLE forge_identities DROP CONSTRAINT forge_identities_user_id_fkey;
+ALTER TABLE pull_request_sources DROP CONSTRAINT pull_request_sources_pull_request_id_fkey;
+ALTER TABLE team_chat_webhooks DROP CONSTRAINT team_chat_webhooks_team_name_fkey;
+
+ALTER TABLE teams DROP CONSTRAINT teams_pkey, ADD PRIMARY KEY (name);
+ALTER TABLE users DROP CONSTRAINT users_pkey, ADD PRIMARY KEY (id);
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey, ADD PRIMARY KEY (id);
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey, ADD PRIMARY KEY (pull_request_id, user_id);
+ALTER TABLE forge_identities DROP CONSTRAINT forge_identities_pkey, ADD PRIMARY KEY (forge, login);
+ALTER TABLE pull_request_sources DROP CONSTRAINT pull_request_sources_pkey, ADD PRIMARY KEY (pull_request_id);
+ALTER TABLE team_chat_webhooks DROP CONSTRAINT team_chat_webhooks_pkey, ADD PRIMARY KEY (team_name);
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey, ADD PRIMARY KEY (key, route);
+
+ALTER TABLE teams DROP COLUMN tenant_id;
+ALTER TABLE users DROP COLUMN tenant_id;
+ALTER TABLE pull_requests DROP COLUMN tenant_id;
+ALTER TABLE pull_request_reviewers DROP COLUMN tenant_id;
+ALTER TABLE pr_events DROP COLUMN tenant_id;
+ALTER TABLE webhooks DROP COLUMN tenant_id;
+ALTER TABLE forge_identities DROP COLUMN tenant_id;
+ALTER TABLE pull_request_sources DROP COLUMN tenant_id;
+ALTER TABLE team_chat_webhooks DROP COLUMN tenant_id;
+ALTER TABLE idempotency_keys DROP COLUMN tenant_id;
+
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES users(id);
+ALTER TABLE pull_request_reviewers
+    ADD CONSTRAINT pull_request_reviewers_pull_request_id_fkey
+        FOREIGN KEY (pull_request_id) REFERENCES pull_requests(id) ON DELETE CASCADE,
+    ADD CONSTRAINT pull_request_reviewers_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES users(id),
+    ADD CONSTRAINT pull_request_reviewers_assigned_by_fkey
+        FOREIGN KEY (assigned_by) REFERENCES users(id);
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE;
+ALTER TABLE forge_identities ADD CONSTRAINT forge_identities_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE pull_request_sources ADD CONSTRAINT pull_request_sources_pull_request_id_fkey
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(id) ON DELETE CASCADE;
+ALTER TABLE team_chat_webhooks ADD CONSTRAINT team_chat_webhooks_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,65 @@
+ALTER TABLE users DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_author_id_fkey;
+ALTER TABLE pull_request_reviewers
+    DROP CONSTRAINT pull_request_reviewers_pull_request_id_fkey,
+    DROP CONSTRAINT pull_request_reviewers_user_id_fkey,
+    DROP CONSTRAINT pull_request_reviewers_assigned_by_fkey;
+ALTER TABLE webhooks DROP CONSTRAINT webhooks_team_name_fkey;
+ALTER TABLE forge_identities DROP CONSTRAINT forge_identities_user_id_fkey;
+ALTER TABLE pull_request_sources DROP CONSTRAINT pull_request_sources_pull_request_id_fkey;
+ALTER TABLE team_chat_webhooks DROP CONSTRAINT team_chat_webhooks_team_name_fkey;
+
+-- existing data lands in the default tenant
+ALTER TABLE teams ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE users ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_request_reviewers ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE pr_events ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE webhooks ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE forge_identities ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_request_sources ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE team_chat_webhooks ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+ALTER TABLE idempotency_keys ADD COLUMN tenant_id VARCHAR(63) NOT NULL DEFAULT 'default';
+
+ALTER TABLE teams ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE pull_request_reviewers ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE pr_events ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE webhooks ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE forge_identities ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE pull_request_sources ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE team_chat_webhooks ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE idempotency_keys ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE teams DROP CONSTRAINT teams_pkey, ADD PRIMARY KEY (tenant_id, name);
+ALTER TABLE users DROP CONSTRAINT users_pkey, ADD PRIMARY KEY (tenant_id, id);
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey, ADD PRIMARY KEY (tenant_id, id);
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey, ADD PRIMARY KEY (tenant_id, pull_request_id, user_id);
+ALTER TABLE forge_identities DROP CONSTRAINT forge_identities_pkey, ADD PRIMARY KEY (tenant_id, forge, login);
+ALTER TABLE pull_request_sources DROP CONSTRAINT pull_request_sources_pkey, ADD PRIMARY KEY (tenant_id, pull_request_id);
+ALTER TABLE team_chat_webhooks DROP CONSTRAINT team_chat_webhooks_pkey, ADD PRIMARY KEY (tenant_id, team_name);
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey, ADD PRIMARY KEY (tenant_id, key, route);
+
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (tenant_id, team_name) REFERENCES teams(tenant_id, name) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (tenant_id, author_id) REFERENCES users(tenant_id, id);
+ALTER TABLE pull_request_reviewers
+    ADD CONSTRAINT pull_request_reviewers_pull_request_id_fkey
+        FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests(tenant_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT pull_request_reviewers_user_id_fkey
+        FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, id),
+    ADD CONSTRAINT pull_request_reviewers_assigned_by_fkey
+        FOREIGN KEY (tenant_id, assigned_by) REFERENCES users(tenant_id, id);
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_team_name_fkey
+    FOREIGN KEY (tenant_id, team_name) REFERENCES teams(tenant_id, name) ON DELETE CASCADE;
+ALTER TABLE forge_identities ADD CONSTRAINT forge_identities_user_id_fkey
+    FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, id) ON DELETE CASCADE;
+ALTER TABLE pull_request_sources ADD CONSTRAINT pull_request_sources_pull_request_id_fkey
+    FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests(tenant_id, id) ON DELETE CASCADE;
+ALTER TABLE team_chat_webhooks ADD CONSTRAINT team_chat_webhooks_team_name_fkey
+    FOREIGN KEY (tenant_id, team_name) REFERENCES teams(tenant_id, name) ON DELETE CASCADE;
+
+CREATE INDEX idx_pr_events_tenant_id ON pr_events(tenant_id);
+CREATE INDEX idx_webhooks_tenant_id ON webhooks(tenant_id);
\ No newline at end of file
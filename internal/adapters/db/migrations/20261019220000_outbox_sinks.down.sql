@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outbox_next_attempt_at;
+CREATE INDEX idx_outbox_next_attempt_at ON outbox(next_attempt_at);
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS failed_at,
+    DROP COLUMN IF EXISTS delivered_sinks;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE outbox
+    ADD COLUMN delivered_sinks TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN failed_at TIMESTAMP WITH TIME ZONE;
+DROP INDEX IF EXISTS idx_outbox_next_attempt_at;
+CREATE INDEX idx_outbox_next_attempt_at ON outbox(next_attempt_at) WHERE failed_at IS NULL;
\ No newline at end of file
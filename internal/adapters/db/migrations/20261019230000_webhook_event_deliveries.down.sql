@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_event_id;
+CREATE INDEX idx_webhook_deliveries_event_id ON webhook_deliveries(event_id) WHERE success;
\ No newline at end of file
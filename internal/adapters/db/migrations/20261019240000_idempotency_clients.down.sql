@@ -0,0 +1,4 @@
+-- keys of different clients may collide once the client is dropped
+DELETE FROM idempotency_keys WHERE client <> '';
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey, ADD PRIMARY KEY (tenant_id, key, route);
+ALTER TABLE idempotency_keys DROP COLUMN client;
\ No newline at end of file
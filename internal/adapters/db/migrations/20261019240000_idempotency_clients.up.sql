@@ -0,0 +1,2 @@
+ALTER TABLE idempotency_keys ADD COLUMN client TEXT NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey, ADD PRIMARY KEY (tenant_id, client, key, route);
\ No newline at end of file
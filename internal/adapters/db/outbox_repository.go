@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// outboxRow reads delivered sinks as a comma separated string.
+type outboxRow struct {
+	ID             int64  `db:"outbox_id"`
+	Attempts       int    `db:"attempts"`
+	DeliveredSinks string `db:"delivered_sinks"`
+	core.Event
+}
+
+func (d *DB) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]core.OutboxEntry, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE outbox
+			SET attempts = attempts + 1, next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+			WHERE id IN (
+				SELECT id FROM outbox
+				WHERE next_attempt_at <= CURRENT_TIMESTAMP AND failed_at IS NULL
+				ORDER BY id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, event_id, attempts, delivered_sinks
+		)
+		SELECT c.id AS outbox_id, c.attempts, array_to_string(c.delivered_sinks, ',') AS delivered_sinks, ` + eventColumns + `
+		FROM claimed c
+		JOIN pr_events e ON e.id = c.event_id
+		ORDER BY c.id
+	`
+
+	var rows []outboxRow
+	if err := d.conn.SelectContext(ctx, &rows, query, limit, lease.Seconds()); err != nil {
+		return nil, fmt.Errorf("claim outbox entries: %w", err)
+	}
+
+	entries := make([]core.OutboxEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = core.OutboxEntry{ID: row.ID, Attempts: row.Attempts, Event: row.Event}
+		if row.DeliveredSinks != "" {
+			entries[i].DeliveredSinks = strings.Split(row.DeliveredSinks, ",")
+		}
+	}
+	return entries, nil
+}
+
+func (d *DB) CompleteOutbox(ctx context.Context, entryID int64) error {
+	query := `DELETE FROM outbox WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, entryID); err != nil {
+		return fmt.Errorf("complete outbox entry %d: %w", entryID, err)
+	}
+	return nil
+}
+
+func (d *DB) RetryOutbox(ctx context.Context, entryID int64, delivered []string, delay time.Duration, lastErr string) error {
+	query := `
+		UPDATE outbox
+		SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2), last_error = $3, delivered_sinks = $4
+		WHERE id = $1
+	`
+	if _, err := d.conn.ExecContext(ctx, query, entryID, delay.Seconds(), lastErr, nonNil(delivered)); err != nil {
+		return fmt.Errorf("reschedule outbox entry %d: %w", entryID, err)
+	}
+	return nil
+}
+
+func (d *DB) FailOutbox(ctx context.Context, entryID int64, delivered []string, lastErr string) error {
+	query := `UPDATE outbox SET failed_at = CURRENT_TIMESTAMP, last_error = $2, delivered_sinks = $3 WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, entryID, lastErr, nonNil(delivered)); err != nil {
+		return fmt.Errorf("fail outbox entry %d: %w", entryID, err)
+	}
+	return nil
+}
+
+// nonNil keeps a NOT NULL array column empty rather than NULL.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
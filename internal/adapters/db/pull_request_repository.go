@@ -9,7 +9,7 @@ import (
 	"github.com/penkovgd/pr-reviews/internal/core"
 )
 
-func (d *DB) CreatePR(ctx context.Context, pr *core.PullRequest) error {
+func (d *DB) CreatePR(ctx context.Context, pr *core.PullRequest, source *core.ForgePullRequest, events []core.Event) error {
 	tx, err := d.conn.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
@@ -20,20 +20,33 @@ func (d *DB) CreatePR(ctx context.Context, pr *core.PullRequest) error {
 		}
 	}()
 
-	query := `INSERT INTO pull_requests (id, name, author_id, status, created_at) VALUES ($1, $2, $3, $4, $5)`
-	_, err = tx.ExecContext(ctx, query, pr.ID, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt)
+	tenantID := core.TenantFromContext(ctx)
+	query := `INSERT INTO pull_requests (id, name, author_id, status, created_at, tenant_id) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = tx.ExecContext(ctx, query, pr.ID, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, tenantID)
 	if err != nil {
 		return fmt.Errorf("insert pull request %s: %w", pr.ID, err)
 	}
 
-	reviewerQuery := `INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
+	reviewerQuery := `INSERT INTO pull_request_reviewers (pull_request_id, user_id, tenant_id) VALUES ($1, $2, $3)`
 	for _, reviewerID := range pr.AssignedReviewers {
-		_, err := tx.ExecContext(ctx, reviewerQuery, pr.ID, reviewerID)
+		_, err := tx.ExecContext(ctx, reviewerQuery, pr.ID, reviewerID, tenantID)
 		if err != nil {
 			return fmt.Errorf("assign reviewer %s to PR %s: %w", reviewerID, pr.ID, err)
 		}
 	}
 
+	if source != nil {
+		sourceQuery := `INSERT INTO pull_request_sources (pull_request_id, forge, repository, number, tenant_id) VALUES ($1, $2, $3, $4, $5)`
+		_, err := tx.ExecContext(ctx, sourceQuery, pr.ID, source.Forge, source.Repository, source.Number, tenantID)
+		if err != nil {
+			return fmt.Errorf("save source of PR %s: %w", pr.ID, err)
+		}
+	}
+
+	if err := insertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("commit pull request creation: %w", err)
 	}
@@ -43,28 +56,37 @@ func (d *DB) CreatePR(ctx context.Context, pr *core.PullRequest) error {
 func (d *DB) GetPRByID(ctx context.Context, prID string) (*core.PullRequest, error) {
 	var pr core.PullRequest
 
-	query := `SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests WHERE id = $1`
-	if err := d.conn.GetContext(ctx, &pr, query, prID); err != nil {
+	query := `SELECT id, name, author_id, status, created_at, merged_at FROM pull_requests WHERE id = $1 AND tenant_id = $2`
+	if err := d.conn.GetContext(ctx, &pr, query, prID, core.TenantFromContext(ctx)); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("pull request %s: %w", prID, core.ErrPRNotFound)
 		}
 		return nil, fmt.Errorf("get pull request %s: %w", prID, err)
 	}
 
-	var reviewers []string
-	reviewerQuery := `SELECT user_id FROM pull_request_reviewers WHERE pull_request_id = $1`
-	if err := d.conn.SelectContext(ctx, &reviewers, reviewerQuery, prID); err != nil {
-		return nil, fmt.Errorf("get reviewers for PR %s: %w", prID, err)
+	reviewers, err := d.getReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
 	}
 
 	pr.AssignedReviewers = reviewers
 	return &pr, nil
 }
 
+func (d *DB) getReviewers(ctx context.Context, prID string) ([]string, error) {
+	var reviewers []string
+	query := `SELECT user_id FROM pull_request_reviewers WHERE pull_request_id = $1 AND tenant_id = $2`
+	if err := d.conn.SelectContext(ctx, &reviewers, query, prID, core.TenantFromContext(ctx)); err != nil {
+		return nil, fmt.Errorf("get reviewers for PR %s: %w", prID, err)
+	}
+	return reviewers, nil
+}
+
 func (d *DB) GetPRsByReviewer(ctx context.Context, userID string) ([]*core.PullRequest, error) {
+	tenantID := core.TenantFromContext(ctx)
 	var user core.User
-	userQuery := `SELECT * FROM users WHERE id = $1`
-	if err := d.conn.GetContext(ctx, &user, userQuery, userID); err != nil {
+	userQuery := `SELECT id, username, team_name, is_active, role FROM users WHERE id = $1 AND tenant_id = $2`
+	if err := d.conn.GetContext(ctx, &user, userQuery, userID, tenantID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("user %s: %w", userID, core.ErrPRNotFound)
 		}
@@ -74,21 +96,20 @@ func (d *DB) GetPRsByReviewer(ctx context.Context, userID string) ([]*core.PullR
 	query := `
 		SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at, pr.merged_at
 		FROM pull_requests pr
-		JOIN pull_request_reviewers prr ON pr.id = prr.pull_request_id
-		WHERE prr.user_id = $1
+		JOIN pull_request_reviewers prr ON pr.tenant_id = prr.tenant_id AND pr.id = prr.pull_request_id
+		WHERE prr.user_id = $1 AND prr.tenant_id = $2
 		ORDER BY pr.created_at DESC
 		`
 
 	var prs []*core.PullRequest
-	if err := d.conn.SelectContext(ctx, &prs, query, userID); err != nil {
+	if err := d.conn.SelectContext(ctx, &prs, query, userID, tenantID); err != nil {
 		return nil, fmt.Errorf("get pull requests for reviewer %s: %w", userID, err)
 	}
 
 	for _, pr := range prs {
-		var reviewers []string
-		reviewerQuery := `SELECT user_id FROM pull_request_reviewers WHERE pull_request_id = $1`
-		if err := d.conn.SelectContext(ctx, &reviewers, reviewerQuery, pr.ID); err != nil {
-			return nil, fmt.Errorf("get reviewers for PR %s: %w", pr.ID, err)
+		reviewers, err := d.getReviewers(ctx, pr.ID)
+		if err != nil {
+			return nil, err
 		}
 		pr.AssignedReviewers = reviewers
 	}
@@ -96,7 +117,32 @@ func (d *DB) GetPRsByReviewer(ctx context.Context, userID string) ([]*core.PullR
 	return prs, nil
 }
 
-func (d *DB) UpdatePR(ctx context.Context, pr *core.PullRequest) error {
+func (d *DB) GetOpenPRsByTeam(ctx context.Context, teamName string) ([]*core.PullRequest, error) {
+	query := `
+		SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at, pr.merged_at
+		FROM pull_requests pr
+		JOIN users u ON pr.tenant_id = u.tenant_id AND pr.author_id = u.id
+		WHERE u.team_name = $1 AND u.tenant_id = $2 AND pr.status = 'OPEN'
+		ORDER BY pr.created_at
+		`
+
+	var prs []*core.PullRequest
+	if err := d.conn.SelectContext(ctx, &prs, query, teamName, core.TenantFromContext(ctx)); err != nil {
+		return nil, fmt.Errorf("get open pull requests of team %s: %w", teamName, err)
+	}
+
+	for _, pr := range prs {
+		reviewers, err := d.getReviewers(ctx, pr.ID)
+		if err != nil {
+			return nil, err
+		}
+		pr.AssignedReviewers = reviewers
+	}
+
+	return prs, nil
+}
+
+func (d *DB) UpdatePR(ctx context.Context, pr *core.PullRequest, events []core.Event) error {
 	tx, err := d.conn.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
@@ -107,8 +153,9 @@ func (d *DB) UpdatePR(ctx context.Context, pr *core.PullRequest) error {
 		}
 	}()
 
-	query := `UPDATE pull_requests SET name = $1, author_id = $2, status = $3, merged_at = $4 WHERE id = $5`
-	result, err := tx.ExecContext(ctx, query, pr.Name, pr.AuthorID, pr.Status, pr.MergedAt, pr.ID)
+	tenantID := core.TenantFromContext(ctx)
+	query := `UPDATE pull_requests SET name = $1, author_id = $2, status = $3, merged_at = $4 WHERE id = $5 AND tenant_id = $6`
+	result, err := tx.ExecContext(ctx, query, pr.Name, pr.AuthorID, pr.Status, pr.MergedAt, pr.ID, tenantID)
 	if err != nil {
 		return fmt.Errorf("update pull request %s: %w", pr.ID, err)
 	}
@@ -122,6 +169,10 @@ func (d *DB) UpdatePR(ctx context.Context, pr *core.PullRequest) error {
 		return fmt.Errorf("pull request %s: %w", pr.ID, core.ErrPRNotFound)
 	}
 
+	if err := insertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
 	// if status MERGED, doesn't update reviewers
 	if pr.Status == core.StatusMerged {
 		if err := tx.Commit(); err != nil {
@@ -130,16 +181,22 @@ func (d *DB) UpdatePR(ctx context.Context, pr *core.PullRequest) error {
 		return nil
 	}
 
-	// delete old reviewers and insert new reviewers
-	deleteQuery := `DELETE FROM pull_request_reviewers WHERE pull_request_id = $1`
-	_, err = tx.ExecContext(ctx, deleteQuery, pr.ID)
+	// delete reviewers which are gone and insert new ones,
+	// kept reviewers preserve their assignment time
+	kept := pr.AssignedReviewers
+	if kept == nil {
+		// nil slice is sent as NULL which would match nothing
+		kept = []string{}
+	}
+	deleteQuery := `DELETE FROM pull_request_reviewers WHERE pull_request_id = $1 AND tenant_id = $3 AND NOT (user_id = ANY($2))`
+	_, err = tx.ExecContext(ctx, deleteQuery, pr.ID, kept, tenantID)
 	if err != nil {
 		return fmt.Errorf("delete old reviewers for PR %s: %w", pr.ID, err)
 	}
 
-	insertQuery := `INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
+	insertQuery := `INSERT INTO pull_request_reviewers (pull_request_id, user_id, tenant_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
 	for _, reviewerID := range pr.AssignedReviewers {
-		_, err := tx.ExecContext(ctx, insertQuery, pr.ID, reviewerID)
+		_, err := tx.ExecContext(ctx, insertQuery, pr.ID, reviewerID, tenantID)
 		if err != nil {
 			return fmt.Errorf("insert reviewer %s for PR %s: %w", reviewerID, pr.ID, err)
 		}
@@ -150,3 +207,163 @@ func (d *DB) UpdatePR(ctx context.Context, pr *core.PullRequest) error {
 	}
 	return nil
 }
+
+func (d *DB) AddReviewer(ctx context.Context, prID, userID, assignedBy string, maxReviewers int, events []core.Event) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			d.log.Error("transaction rollback", "error", err)
+		}
+	}()
+
+	tenantID := core.TenantFromContext(ctx)
+	// the PR row is locked, so concurrent additions count the reviewers one after another
+	var status core.PullRequestStatus
+	query := `SELECT status FROM pull_requests WHERE id = $1 AND tenant_id = $2 FOR UPDATE`
+	if err := tx.GetContext(ctx, &status, query, prID, tenantID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("pull request %s: %w", prID, core.ErrPRNotFound)
+		}
+		return fmt.Errorf("lock pull request %s: %w", prID, err)
+	}
+	if status == core.StatusMerged {
+		return core.ErrPRMerged
+	}
+
+	var reviewers int
+	query = `SELECT COUNT(*) FROM pull_request_reviewers WHERE pull_request_id = $1 AND tenant_id = $2`
+	if err := tx.GetContext(ctx, &reviewers, query, prID, tenantID); err != nil {
+		return fmt.Errorf("count reviewers of PR %s: %w", prID, err)
+	}
+	if reviewers >= maxReviewers {
+		return core.ErrTooManyReviewers
+	}
+
+	query = `INSERT INTO pull_request_reviewers (pull_request_id, user_id, assigned_by, tenant_id) VALUES ($1, $2, NULLIF($3, ''), $4)
+	ON CONFLICT DO NOTHING`
+	result, err := tx.ExecContext(ctx, query, prID, userID, assignedBy, tenantID)
+	if err != nil {
+		return fmt.Errorf("add reviewer %s to PR %s: %w", userID, prID, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected for PR %s: %w", prID, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("reviewer %s of PR %s: %w", userID, prID, core.ErrReviewerAssigned)
+	}
+
+	if err := insertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit reviewer addition: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) RemoveReviewer(ctx context.Context, prID, userID string, events []core.Event) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			d.log.Error("transaction rollback", "error", err)
+		}
+	}()
+
+	query := `DELETE FROM pull_request_reviewers WHERE pull_request_id = $1 AND user_id = $2 AND tenant_id = $3`
+	result, err := tx.ExecContext(ctx, query, prID, userID, core.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("remove reviewer %s from PR %s: %w", userID, prID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected for PR %s: %w", prID, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reviewer %s of PR %s: %w", userID, prID, core.ErrReviewerNotAssigned)
+	}
+
+	if err := insertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit reviewer removal: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) GetOpenAssignments(ctx context.Context) ([]core.ReviewAssignment, error) {
+	query := `
+		SELECT prr.pull_request_id, prr.user_id, u.team_name,
+			COALESCE(prr.assigned_at, pr.created_at, CURRENT_TIMESTAMP) AS assigned_at,
+			prr.reminded_at, prr.escalated_at, prr.tenant_id
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.tenant_id = prr.tenant_id AND pr.id = prr.pull_request_id
+		JOIN users u ON u.tenant_id = pr.tenant_id AND u.id = pr.author_id
+		WHERE pr.status = 'OPEN'
+		ORDER BY assigned_at
+		`
+
+	var assignments []core.ReviewAssignment
+	if err := d.conn.SelectContext(ctx, &assignments, query); err != nil {
+		return nil, fmt.Errorf("get open assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+func (d *DB) MarkReminded(ctx context.Context, prID, userID string, events []core.Event) (bool, error) {
+	return d.markAssignment(ctx, "reminded_at", prID, userID, events)
+}
+
+func (d *DB) MarkEscalated(ctx context.Context, prID, userID string, events []core.Event) (bool, error) {
+	return d.markAssignment(ctx, "escalated_at", prID, userID, events)
+}
+
+// markAssignment sets the timestamp column of the assignment if it is not set, column is never
+// user input. Replicas running the stale review job concurrently store the events only once.
+func (d *DB) markAssignment(ctx context.Context, column, prID, userID string, events []core.Event) (bool, error) {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			d.log.Error("transaction rollback", "error", err)
+		}
+	}()
+
+	query := `UPDATE pull_request_reviewers SET ` + column + ` = CURRENT_TIMESTAMP
+	WHERE pull_request_id = $1 AND user_id = $2 AND tenant_id = $3 AND ` + column + ` IS NULL`
+	result, err := tx.ExecContext(ctx, query, prID, userID, core.TenantFromContext(ctx))
+	if err != nil {
+		return false, fmt.Errorf("set %s of reviewer %s of PR %s: %w", column, userID, prID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("get rows affected for PR %s: %w", prID, err)
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if err := insertEvents(ctx, tx, events); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit %s of reviewer: %w", column, err)
+	}
+	return true, nil
+}
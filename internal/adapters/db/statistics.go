@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/penkovgd/closer"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
 )
 
 func (d *DB) GetUserAssignmentStats(ctx context.Context) (map[string]int, error) {
@@ -13,13 +15,13 @@ func (d *DB) GetUserAssignmentStats(ctx context.Context) (map[string]int, error)
             u.id as user_id,
             COALESCE(COUNT(prr.user_id), 0) as assignment_count
         FROM users u
-        LEFT JOIN pull_request_reviewers prr ON u.id = prr.user_id
-        WHERE u.is_active = true
+        LEFT JOIN pull_request_reviewers prr ON u.tenant_id = prr.tenant_id AND u.id = prr.user_id
+        WHERE u.is_active = true AND u.tenant_id = $1
         GROUP BY u.id
         ORDER BY assignment_count DESC
     `
 
-	rows, err := d.conn.QueryxContext(ctx, query)
+	rows, err := d.conn.QueryxContext(ctx, query, core.TenantFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("get user assignment stats: %w", err)
 	}
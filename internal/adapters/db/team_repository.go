@@ -5,23 +5,68 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/penkovgd/pr-reviews/internal/core"
 )
 
-func (d *DB) CreateTeam(ctx context.Context, team *core.Team) error {
-	query := `INSERT INTO teams (name) VALUES ($1)`
-	_, err := d.conn.ExecContext(ctx, query, team.Name)
+// teamRow mirrors the teams table, SLA durations are stored in seconds.
+type teamRow struct {
+	core.ReviewPolicy
+	RemindAfter   int64  `db:"sla_remind_after_seconds"`
+	EscalateAfter int64  `db:"sla_escalate_after_seconds"`
+	Escalation    string `db:"sla_escalation"`
+}
+
+func (r *teamRow) sla() core.ReviewSLA {
+	return core.ReviewSLA{
+		RemindAfter:   time.Duration(r.RemindAfter) * time.Second,
+		EscalateAfter: time.Duration(r.EscalateAfter) * time.Second,
+		Escalation:    core.EscalationAction(r.Escalation),
+	}
+}
+
+func (d *DB) CreateTeam(ctx context.Context, team *core.Team, events []core.Event) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			d.log.Error("transaction rollback", "error", err)
+		}
+	}()
+
+	query := `INSERT INTO teams (name, required_role, required_count, max_reviewers,
+	sla_remind_after_seconds, sla_escalate_after_seconds, sla_escalation, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = tx.ExecContext(ctx, query, team.Name, team.Policy.RequiredRole, team.Policy.RequiredCount, team.Policy.MaxReviewers,
+		int64(team.SLA.RemindAfter.Seconds()), int64(team.SLA.EscalateAfter.Seconds()), team.SLA.Escalation, core.TenantFromContext(ctx))
 	if err != nil {
 		return fmt.Errorf("create team %s: %w", team.Name, err)
 	}
+
+	for i := range team.Members {
+		if err := upsertUser(ctx, tx, &team.Members[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := insertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit team creation: %w", err)
+	}
 	return nil
 }
 
 func (d *DB) GetTeamByName(ctx context.Context, teamName string) (*core.Team, error) {
-	var team core.Team
-	query := `SELECT * FROM teams WHERE name = $1`
-	err := d.conn.GetContext(ctx, &team, query, teamName)
+	tenantID := core.TenantFromContext(ctx)
+	var row teamRow
+	query := `SELECT required_role, required_count, max_reviewers,
+	sla_remind_after_seconds, sla_escalate_after_seconds, sla_escalation FROM teams WHERE name = $1 AND tenant_id = $2`
+	err := d.conn.GetContext(ctx, &row, query, teamName, tenantID)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -30,12 +75,13 @@ func (d *DB) GetTeamByName(ctx context.Context, teamName string) (*core.Team, er
 		return nil, fmt.Errorf("get team %s: %w", teamName, err)
 	}
 
-	usersQuery := `SELECT id, username, team_name, is_active FROM users WHERE team_name = $1`
+	usersQuery := `SELECT id, username, team_name, is_active, role, email, email_digest, tenant_id FROM users
+	WHERE team_name = $1 AND tenant_id = $2`
 	var users []core.User
-	err = d.conn.SelectContext(ctx, &users, usersQuery, teamName)
+	err = d.conn.SelectContext(ctx, &users, usersQuery, teamName, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("get team %s users: %w", teamName, err)
 	}
 
-	return &core.Team{Name: teamName, Members: users}, nil
+	return &core.Team{Name: teamName, Policy: row.ReviewPolicy, SLA: row.sla(), Members: users}, nil
 }
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentation = "github.com/penkovgd/pr-reviews/internal/adapters/db"
+
+// QueryObserver receives the duration and error of every statement. Operation is the
+// statement verb and its first table, e.g. "select users", so it stays low in cardinality.
+type QueryObserver func(operation string, duration time.Duration, err error)
+
+var tablePattern = regexp.MustCompile(`(?i)\b(?:from|into|update|join)\s+([a-z_][a-z0-9_]*)`)
+
+// queryOperation names a statement by its verb and first table, the verb alone if it has none.
+func queryOperation(sql string) string {
+	verb, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+	verb = strings.ToLower(strings.TrimSpace(verb))
+	if match := tablePattern.FindStringSubmatch(sql); match != nil {
+		return verb + " " + strings.ToLower(match[1])
+	}
+	return verb
+}
+
+// queryTracer starts a client span of every statement traced by pgx and passes
+// the statement to the observer, if any.
+type queryTracer struct {
+	observe QueryObserver
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	operation string
+	at        time.Time
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryOperation(data.SQL)
+	ctx, _ = otel.Tracer(instrumentation).Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBQueryText(data.SQL)),
+	)
+	return context.WithValue(ctx, queryStartKey{}, queryStart{operation: operation, at: time.Now()})
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.End()
+
+	if start, ok := ctx.Value(queryStartKey{}).(queryStart); ok && t.observe != nil {
+		t.observe(start.operation, time.Since(start.at), data.Err)
+	}
+}
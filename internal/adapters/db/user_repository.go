@@ -6,19 +6,48 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/penkovgd/pr-reviews/internal/core"
 )
 
-func (d *DB) UpsertUser(ctx context.Context, user *core.User) error {
+func (d *DB) UpsertUser(ctx context.Context, user *core.User, events []core.Event) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			d.log.Error("transaction rollback", "error", err)
+		}
+	}()
+
+	if err := upsertUser(ctx, tx, user); err != nil {
+		return err
+	}
+
+	if err := insertEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit user update: %w", err)
+	}
+	return nil
+}
+
+func upsertUser(ctx context.Context, tx *sqlx.Tx, user *core.User) error {
 	query := `
-        INSERT INTO users (id, username, team_name, is_active) 
-        VALUES ($1, $2, $3, $4)
-        ON CONFLICT (id) DO UPDATE SET
+        INSERT INTO users (id, username, team_name, is_active, role, email, tenant_id) 
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (tenant_id, id) DO UPDATE SET
             username = EXCLUDED.username,
             team_name = EXCLUDED.team_name,
-            is_active = EXCLUDED.is_active
+            is_active = EXCLUDED.is_active,
+            role = EXCLUDED.role,
+            email = COALESCE(NULLIF(EXCLUDED.email, ''), users.email)
     `
-	_, err := d.conn.ExecContext(ctx, query, user.ID, user.Username, user.TeamName, user.IsActive)
+	_, err := tx.ExecContext(ctx, query, user.ID, user.Username, user.TeamName, user.IsActive, user.Role, user.Email, core.TenantFromContext(ctx))
 	if err != nil {
 		return fmt.Errorf("create or update user %s: %w", user.ID, err)
 	}
@@ -27,8 +56,9 @@ func (d *DB) UpsertUser(ctx context.Context, user *core.User) error {
 
 func (d *DB) GetUserByID(ctx context.Context, userID string) (*core.User, error) {
 	var user core.User
-	query := `SELECT id, username, team_name, is_active FROM users WHERE id = $1`
-	err := d.conn.GetContext(ctx, &user, query, userID)
+	query := `SELECT id, username, team_name, is_active, role, email, email_digest, tenant_id FROM users
+	WHERE id = $1 AND tenant_id = $2`
+	err := d.conn.GetContext(ctx, &user, query, userID, core.TenantFromContext(ctx))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("user %s: %w", userID, core.ErrUserNotFound)
@@ -40,10 +70,39 @@ func (d *DB) GetUserByID(ctx context.Context, userID string) (*core.User, error)
 
 func (d *DB) GetUsersByTeam(ctx context.Context, teamName string) ([]*core.User, error) {
 	var users []*core.User
-	query := `SELECT id, username, team_name, is_active FROM users WHERE team_name = $1`
-	err := d.conn.SelectContext(ctx, &users, query, teamName)
+	query := `SELECT id, username, team_name, is_active, role, email, email_digest, tenant_id FROM users
+	WHERE team_name = $1 AND tenant_id = $2`
+	err := d.conn.SelectContext(ctx, &users, query, teamName, core.TenantFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("get users for team %s: %w", teamName, err)
 	}
 	return users, nil
 }
+
+func (d *DB) SetEmailDigest(ctx context.Context, userID string, enabled bool) error {
+	query := `UPDATE users SET email_digest = $2 WHERE id = $1 AND tenant_id = $3`
+	result, err := d.conn.ExecContext(ctx, query, userID, enabled, core.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("set email digest of user %s: %w", userID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected for user %s: %w", userID, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user %s: %w", userID, core.ErrUserNotFound)
+	}
+	return nil
+}
+
+func (d *DB) GetDigestRecipients(ctx context.Context) ([]*core.User, error) {
+	var users []*core.User
+	query := `SELECT id, username, team_name, is_active, role, email, email_digest, tenant_id FROM users
+	WHERE is_active AND email_digest AND email <> '' ORDER BY tenant_id, id`
+	if err := d.conn.SelectContext(ctx, &users, query); err != nil {
+		return nil, fmt.Errorf("get digest recipients: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// webhookRow mirrors core.Webhook, events are stored as a text array
+// and read back as a comma separated string.
+type webhookRow struct {
+	core.Webhook
+	EventList string `db:"events"`
+}
+
+func (r *webhookRow) toWebhook() *core.Webhook {
+	webhook := r.Webhook
+	if r.EventList != "" {
+		for _, e := range strings.Split(r.EventList, ",") {
+			webhook.Events = append(webhook.Events, core.EventType(e))
+		}
+	}
+	return &webhook
+}
+
+const webhookColumns = `id, team_name, url, secret, array_to_string(events, ',') AS events, created_at`
+
+func (d *DB) CreateWebhook(ctx context.Context, webhook *core.Webhook) error {
+	events := make([]string, len(webhook.Events))
+	for i, e := range webhook.Events {
+		events[i] = string(e)
+	}
+
+	query := `INSERT INTO webhooks (team_name, url, secret, events, created_at, tenant_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	err := d.conn.QueryRowxContext(ctx, query, webhook.TeamName, webhook.URL, webhook.Secret, events, webhook.CreatedAt,
+		core.TenantFromContext(ctx)).Scan(&webhook.ID)
+	if err != nil {
+		return fmt.Errorf("create webhook for team %s: %w", webhook.TeamName, err)
+	}
+	return nil
+}
+
+func (d *DB) GetWebhook(ctx context.Context, webhookID int64) (*core.Webhook, error) {
+	var row webhookRow
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1 AND tenant_id = $2`
+	if err := d.conn.GetContext(ctx, &row, query, webhookID, core.TenantFromContext(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("webhook %d: %w", webhookID, core.ErrWebhookNotFound)
+		}
+		return nil, fmt.Errorf("get webhook %d: %w", webhookID, err)
+	}
+	return row.toWebhook(), nil
+}
+
+func (d *DB) GetWebhooksByTeam(ctx context.Context, teamName string) ([]*core.Webhook, error) {
+	var rows []webhookRow
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE team_name = $1 AND tenant_id = $2 ORDER BY id`
+	if err := d.conn.SelectContext(ctx, &rows, query, teamName, core.TenantFromContext(ctx)); err != nil {
+		return nil, fmt.Errorf("get webhooks for team %s: %w", teamName, err)
+	}
+
+	webhooks := make([]*core.Webhook, len(rows))
+	for i := range rows {
+		webhooks[i] = rows[i].toWebhook()
+	}
+	return webhooks, nil
+}
+
+func (d *DB) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	query := `DELETE FROM webhooks WHERE id = $1 AND tenant_id = $2`
+	result, err := d.conn.ExecContext(ctx, query, webhookID, core.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("delete webhook %d: %w", webhookID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected for webhook %d: %w", webhookID, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook %d: %w", webhookID, core.ErrWebhookNotFound)
+	}
+	return nil
+}
+
+func (d *DB) AddDelivery(ctx context.Context, delivery *core.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, attempt, status_code, error, success, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+	err := d.conn.QueryRowxContext(ctx, query,
+		delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.Success, delivery.CreatedAt,
+	).Scan(&delivery.ID)
+	if err != nil {
+		return fmt.Errorf("add delivery for webhook %d: %w", delivery.WebhookID, err)
+	}
+	return nil
+}
+
+func (d *DB) GetEventDeliveries(ctx context.Context, eventID int64) ([]*core.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, event_id, event_type, attempt, status_code, error, success, created_at
+		FROM webhook_deliveries
+		WHERE event_id = $1
+		ORDER BY id
+	`
+	var deliveries []*core.WebhookDelivery
+	if err := d.conn.SelectContext(ctx, &deliveries, query, eventID); err != nil {
+		return nil, fmt.Errorf("get deliveries of event %d: %w", eventID, err)
+	}
+	return deliveries, nil
+}
+
+func (d *DB) GetDeliveries(ctx context.Context, webhookID int64, limit int) ([]*core.WebhookDelivery, error) {
+	query := `
+		SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.attempt, d.status_code, d.error, d.success, d.created_at
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.webhook_id = $1 AND w.tenant_id = $3
+		ORDER BY d.id DESC
+		LIMIT $2
+	`
+	var deliveries []*core.WebhookDelivery
+	if err := d.conn.SelectContext(ctx, &deliveries, query, webhookID, limit, core.TenantFromContext(ctx)); err != nil {
+		return nil, fmt.Errorf("get deliveries for webhook %d: %w", webhookID, err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,159 @@
+// Package email sends the daily digest of review requests over SMTP.
+package email
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/schedule"
+	"github.com/penkovgd/pr-reviews/internal/adapters/textfmt"
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+//go:embed templates
+var defaultTemplates embed.FS
+
+// DigestMessage is the data of digest templates.
+type DigestMessage struct {
+	User *core.User
+	PRs  []DigestPR
+}
+
+type DigestPR struct {
+	*core.PullRequest
+	Age time.Duration
+}
+
+// Digest emails every recipient the open PRs they are requested to review.
+type Digest struct {
+	log      *slog.Logger
+	userRepo core.UserRepository
+	users    core.UserService
+	cfg      config.EmailConfig
+	text     *template.Template
+	html     *htmltemplate.Template
+}
+
+func NewDigest(log *slog.Logger, userRepo core.UserRepository, users core.UserService, cfg config.EmailConfig) (*Digest, error) {
+	textSource, err := readTemplate(cfg.TextTemplate, "templates/digest.txt")
+	if err != nil {
+		return nil, err
+	}
+	text, err := template.New("digest.txt").Funcs(template.FuncMap{"age": textfmt.Age}).Parse(textSource)
+	if err != nil {
+		return nil, fmt.Errorf("parse text template: %w", err)
+	}
+
+	htmlSource, err := readTemplate(cfg.HTMLTemplate, "templates/digest.html")
+	if err != nil {
+		return nil, err
+	}
+	html, err := htmltemplate.New("digest.html").Funcs(htmltemplate.FuncMap{"age": textfmt.Age}).Parse(htmlSource)
+	if err != nil {
+		return nil, fmt.Errorf("parse html template: %w", err)
+	}
+
+	return &Digest{
+		log:      log,
+		userRepo: userRepo,
+		users:    users,
+		cfg:      cfg,
+		text:     text,
+		html:     html,
+	}, nil
+}
+
+// readTemplate reads the template file at path or the embedded default if path is empty.
+func readTemplate(path, defaultPath string) (string, error) {
+	var data []byte
+	var err error
+	if path != "" {
+		data, err = os.ReadFile(path)
+	} else {
+		data, err = defaultTemplates.ReadFile(defaultPath)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read template: %w", err)
+	}
+	return string(data), nil
+}
+
+// Run sends digests every day at cfg.DigestAt local time until ctx is done.
+func (d *Digest) Run(ctx context.Context) error {
+	return schedule.Daily(ctx, d.log.With("job", "email digest"), d.cfg.DigestAt, d.SendDigests)
+}
+
+// SendDigests emails each recipient of every tenant with open review requests, the others are skipped.
+func (d *Digest) SendDigests(ctx context.Context) error {
+	recipients, err := d.userRepo.GetDigestRecipients(ctx)
+	if err != nil {
+		return fmt.Errorf("get recipients: %w", err)
+	}
+
+	now := time.Now()
+	var errs []error
+	for _, user := range recipients {
+		prs, err := d.users.GetUserReviewRequests(core.WithTenant(ctx, user.TenantID), user.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", user.ID, err))
+			continue
+		}
+
+		msg := DigestMessage{User: user}
+		for _, pr := range prs {
+			if pr.Status != core.StatusOpen {
+				continue
+			}
+			digestPR := DigestPR{PullRequest: pr}
+			if pr.CreatedAt != nil {
+				digestPR.Age = now.Sub(*pr.CreatedAt)
+			}
+			msg.PRs = append(msg.PRs, digestPR)
+		}
+		if len(msg.PRs) == 0 {
+			continue
+		}
+
+		if err := d.send(msg); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", user.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Digest) send(msg DigestMessage) error {
+	var text, html bytes.Buffer
+	if err := d.text.Execute(&text, msg); err != nil {
+		return fmt.Errorf("render text: %w", err)
+	}
+	if err := d.html.Execute(&html, msg); err != nil {
+		return fmt.Errorf("render html: %w", err)
+	}
+
+	body, err := buildMessage(d.cfg.From, msg.User.Email, d.cfg.Subject, text.Bytes(), html.Bytes())
+	if err != nil {
+		return fmt.Errorf("build message: %w", err)
+	}
+
+	var auth smtp.Auth
+	if d.cfg.Username != "" {
+		auth = smtp.PlainAuth("", d.cfg.Username, d.cfg.Password, d.cfg.Host)
+	}
+	addr := net.JoinHostPort(d.cfg.Host, strconv.Itoa(d.cfg.Port))
+	if err := smtp.SendMail(addr, auth, d.cfg.From, []string{msg.User.Email}, body); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type receivedMail struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// startSMTPStub accepts mail on a local port, received messages are sent to the channel.
+func startSMTPStub(t *testing.T) (string, int, <-chan receivedMail) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	mails := make(chan receivedMail, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, mails)
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, mails
+}
+
+func serveSMTP(conn net.Conn, mails chan<- receivedMail) {
+	defer func() { _ = conn.Close() }()
+	tp := textproto.NewConn(conn)
+
+	var m receivedMail
+	_ = tp.PrintfLine("220 stub ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			_ = tp.PrintfLine("250 stub")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			m = receivedMail{From: strings.Trim(line[len("MAIL FROM:"):], "<>")}
+			_ = tp.PrintfLine("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			m.To = append(m.To, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "DATA":
+			_ = tp.PrintfLine("354 end with .")
+			if m.Data, err = tp.ReadDotBytes(); err != nil {
+				return
+			}
+			mails <- m
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+type fakeUserRepo struct {
+	core.UserRepository
+	users []*core.User
+}
+
+func (r *fakeUserRepo) GetDigestRecipients(context.Context) ([]*core.User, error) {
+	return r.users, nil
+}
+
+type fakeUserService struct {
+	core.UserService
+	reviews map[string][]*core.PullRequest
+}
+
+func (s *fakeUserService) GetUserReviewRequests(_ context.Context, userID string) ([]*core.PullRequest, error) {
+	return s.reviews[userID], nil
+}
+
+// readParts returns bodies of the message parts by content type.
+func readParts(t *testing.T, data []byte) (*mail.Message, map[string]string) {
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(string(data))))
+	if err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("parse content type: %v", err)
+	}
+
+	parts := make(map[string]string)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("next part: %v", err)
+		}
+		body, err := io.ReadAll(quotedprintable.NewReader(p))
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		mediaType, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		parts[mediaType] = string(body)
+	}
+	return msg, parts
+}
+
+func TestDigest_SendDigests(t *testing.T) {
+	host, port, mails := startSMTPStub(t)
+
+	created := time.Now().Add(-3 * time.Hour)
+	users := &fakeUserService{reviews: map[string][]*core.PullRequest{
+		"u1": {
+			{ID: "pr-1", Name: "Fix <nil> deref", AuthorID: "u3", Status: core.StatusOpen, CreatedAt: &created},
+			{ID: "pr-2", Name: "Old one", AuthorID: "u3", Status: core.StatusMerged, CreatedAt: &created},
+		},
+		"u2": {
+			{ID: "pr-2", Name: "Old one", AuthorID: "u3", Status: core.StatusMerged, CreatedAt: &created},
+		},
+	}}
+	userRepo := &fakeUserRepo{users: []*core.User{
+		{ID: "u1", Username: "Alice", Email: "alice@example.com"},
+		{ID: "u2", Username: "Bob", Email: "bob@example.com"},
+	}}
+	cfg := config.EmailConfig{
+		Host:    host,
+		Port:    port,
+		From:    "reviews@example.com",
+		Subject: "Your open review requests",
+	}
+
+	digest, err := NewDigest(slog.Default(), userRepo, users, cfg)
+	if err != nil {
+		t.Fatalf("new digest: %v", err)
+	}
+	if err := digest.SendDigests(context.Background()); err != nil {
+		t.Fatalf("send digests: %v", err)
+	}
+
+	var m receivedMail
+	select {
+	case m = <-mails:
+	case <-time.After(time.Second):
+		t.Fatal("no mail received")
+	}
+	if m.From != cfg.From || len(m.To) != 1 || m.To[0] != "alice@example.com" {
+		t.Errorf("envelope = %s -> %v", m.From, m.To)
+	}
+	if len(mails) != 0 {
+		t.Error("users without open review requests must not be emailed")
+	}
+
+	msg, parts := readParts(t, m.Data)
+	if got := msg.Header.Get("Subject"); got != cfg.Subject {
+		t.Errorf("subject = %q", got)
+	}
+	if text := parts["text/plain"]; !strings.Contains(text, "- Fix <nil> deref (pr-1) by u3, open for 3h") || strings.Contains(text, "pr-2") {
+		t.Errorf("text part = %q", text)
+	}
+	if html := parts["text/html"]; !strings.Contains(html, "Fix &lt;nil&gt; deref (pr-1)") {
+		t.Errorf("html part = %q", html)
+	}
+}
+
+func TestNewDigest_CustomTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digest.txt")
+	if err := os.WriteFile(path, []byte("{{.User.Username}}: {{len .PRs}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewDigest(slog.Default(), &fakeUserRepo{}, &fakeUserService{}, config.EmailConfig{TextTemplate: path}); err != nil {
+		t.Fatalf("new digest: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("{{.User"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewDigest(slog.Default(), &fakeUserRepo{}, &fakeUserService{}, config.EmailConfig{TextTemplate: path}); err == nil {
+		t.Fatal("invalid template must be rejected")
+	}
+}
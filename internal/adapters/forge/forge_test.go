@@ -0,0 +1,145 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type fakeIdentities struct {
+	core.IdentityRepository
+	logins map[string]string
+}
+
+func (r *fakeIdentities) GetIdentityByUser(_ context.Context, forge core.Forge, userID string) (*core.ForgeIdentity, error) {
+	login, ok := r.logins[userID]
+	if !ok {
+		return nil, core.ErrIdentityNotFound
+	}
+	return &core.ForgeIdentity{Forge: forge, Login: login, UserID: userID}, nil
+}
+
+type fakeSources struct {
+	core.SourceRepository
+	sources []core.ForgePullRequest
+}
+
+func (r *fakeSources) GetPRSource(_ context.Context, prID string) (*core.ForgePullRequest, error) {
+	for _, source := range r.sources {
+		if source.PRID() == prID {
+			return &source, nil
+		}
+	}
+	return nil, core.ErrPRSourceNotFound
+}
+
+type recordedCall struct {
+	Method    string
+	Path      string
+	Auth      string
+	Reviewers []string
+}
+
+// fakeForge records requested reviewers calls and answers them with status.
+type fakeForge struct {
+	mu     sync.Mutex
+	calls  []recordedCall
+	status int
+}
+
+func (f *fakeForge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body reviewersRequest
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, recordedCall{
+		Method:    r.Method,
+		Path:      r.URL.EscapedPath(),
+		Auth:      r.Header.Get("Authorization"),
+		Reviewers: body.Reviewers,
+	})
+	w.WriteHeader(f.status)
+}
+
+func TestGitHubSink_MirrorsReviewerChanges(t *testing.T) {
+	fake := &fakeForge{status: http.StatusCreated}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewGitHubClient(slog.Default(), server.URL+"/", "gh-token", time.Second)
+	identities := &fakeIdentities{logins: map[string]string{"u1": "alice", "u2": "bob"}}
+	source := core.ForgePullRequest{Forge: core.ForgeGitHub, Repository: "octo-org/octo-repo", Number: 42}
+	sources := &fakeSources{sources: []core.ForgePullRequest{source}}
+	sink := NewSink(slog.Default(), sources, identities, map[core.Forge]core.ForgeClient{core.ForgeGitHub: client})
+
+	prID := source.PRID()
+	events := []core.Event{
+		{Type: core.EventReviewerAssigned, PRID: prID, UserID: "u1"},
+		{Type: core.EventReviewerReassigned, PRID: prID, UserID: "u2", OldUserID: "u1"},
+		// no login, skipped
+		{Type: core.EventReviewerAssigned, PRID: prID, UserID: "u3"},
+		// not mirrored, skipped
+		{Type: core.EventReviewerAssigned, PRID: "pr-1", UserID: "u1"},
+		// created through the API with an ID that looks mirrored, skipped
+		{Type: core.EventReviewerAssigned, PRID: "github:octo-org/octo-repo#7", UserID: "u1"},
+		{Type: core.EventPRMerged, PRID: prID},
+	}
+	for _, e := range events {
+		if err := sink.Send(context.Background(), e); err != nil {
+			t.Fatalf("send %s: %v", e.Type, err)
+		}
+	}
+
+	const path = "/repos/octo-org/octo-repo/pulls/42/requested_reviewers"
+	want := []recordedCall{
+		{Method: http.MethodPost, Path: path, Auth: "Bearer gh-token", Reviewers: []string{"alice"}},
+		{Method: http.MethodPost, Path: path, Auth: "Bearer gh-token", Reviewers: []string{"bob"}},
+		{Method: http.MethodDelete, Path: path, Auth: "Bearer gh-token", Reviewers: []string{"alice"}},
+	}
+	if !slices.EqualFunc(fake.calls, want, func(a, b recordedCall) bool {
+		return a.Method == b.Method && a.Path == b.Path && a.Auth == b.Auth && slices.Equal(a.Reviewers, b.Reviewers)
+	}) {
+		t.Errorf("calls = %+v, want %+v", fake.calls, want)
+	}
+}
+
+func TestGiteaSink_RetriesOnlyTemporaryFailures(t *testing.T) {
+	fake := &fakeForge{status: http.StatusUnprocessableEntity}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewGiteaClient(slog.Default(), server.URL, "gitea-token", time.Second)
+	identities := &fakeIdentities{logins: map[string]string{"u1": "alice"}}
+	sources := &fakeSources{sources: []core.ForgePullRequest{{Forge: core.ForgeGitea, Repository: "team/app", Number: 7}}}
+	sink := NewSink(slog.Default(), sources, identities, map[core.Forge]core.ForgeClient{core.ForgeGitea: client})
+
+	event := core.Event{Type: core.EventReviewerRemoved, PRID: "gitea:team/app#7", UserID: "u1"}
+	if err := sink.Send(context.Background(), event); err != nil {
+		t.Fatalf("permanent failure must be dropped, got %v", err)
+	}
+
+	fake.status = http.StatusServiceUnavailable
+	if err := sink.Send(context.Background(), event); err == nil {
+		t.Fatal("temporary failure must be returned for a retry")
+	}
+
+	if len(fake.calls) != 2 {
+		t.Fatalf("calls = %d, want 2", len(fake.calls))
+	}
+	call := fake.calls[0]
+	if call.Method != http.MethodDelete || call.Path != "/api/v1/repos/team/app/pulls/7/requested_reviewers" {
+		t.Errorf("call = %s %s", call.Method, call.Path)
+	}
+	if call.Auth != "token gitea-token" {
+		t.Errorf("authorization = %q", call.Auth)
+	}
+}
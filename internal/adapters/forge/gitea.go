@@ -0,0 +1,37 @@
+package forge
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// GiteaClient requests reviews through the Gitea REST API, Forgejo is compatible.
+type GiteaClient struct {
+	api apiClient
+}
+
+// NewGiteaClient expects the instance URL as baseURL, e.g. https://gitea.example.com.
+func NewGiteaClient(log *slog.Logger, baseURL, token string, timeout time.Duration) *GiteaClient {
+	return &GiteaClient{api: apiClient{
+		log:     log,
+		client:  &http.Client{Timeout: timeout},
+		baseURL: strings.TrimSuffix(baseURL, "/") + "/api/v1",
+		headers: http.Header{
+			"Accept":        {"application/json"},
+			"Authorization": {"token " + token},
+		},
+	}}
+}
+
+func (c *GiteaClient) RequestReviewers(ctx context.Context, pr core.ForgePullRequest, logins []string) error {
+	return c.api.do(ctx, http.MethodPost, reviewersPath(pr.Repository, pr.Number), reviewersRequest{Reviewers: logins})
+}
+
+func (c *GiteaClient) RemoveReviewers(ctx context.Context, pr core.ForgePullRequest, logins []string) error {
+	return c.api.do(ctx, http.MethodDelete, reviewersPath(pr.Repository, pr.Number), reviewersRequest{Reviewers: logins})
+}
@@ -0,0 +1,39 @@
+package forge
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// GitHubClient requests reviews through the GitHub REST API.
+type GitHubClient struct {
+	api apiClient
+}
+
+// NewGitHubClient expects the API root as baseURL, https://api.github.com
+// or https://HOST/api/v3 for GitHub Enterprise Server.
+func NewGitHubClient(log *slog.Logger, baseURL, token string, timeout time.Duration) *GitHubClient {
+	return &GitHubClient{api: apiClient{
+		log:     log,
+		client:  &http.Client{Timeout: timeout},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		headers: http.Header{
+			"Accept":               {"application/vnd.github+json"},
+			"Authorization":        {"Bearer " + token},
+			"X-Github-Api-Version": {"2022-11-28"},
+		},
+	}}
+}
+
+func (c *GitHubClient) RequestReviewers(ctx context.Context, pr core.ForgePullRequest, logins []string) error {
+	return c.api.do(ctx, http.MethodPost, reviewersPath(pr.Repository, pr.Number), reviewersRequest{Reviewers: logins})
+}
+
+func (c *GitHubClient) RemoveReviewers(ctx context.Context, pr core.ForgePullRequest, logins []string) error {
+	return c.api.do(ctx, http.MethodDelete, reviewersPath(pr.Repository, pr.Number), reviewersRequest{Reviewers: logins})
+}
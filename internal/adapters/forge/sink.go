@@ -0,0 +1,89 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// Sink mirrors reviewer changes of PRs mirrored from a forge back to it, it is an outbox sink.
+type Sink struct {
+	log        *slog.Logger
+	sources    core.SourceRepository
+	identities core.IdentityRepository
+	clients    map[core.Forge]core.ForgeClient
+}
+
+func NewSink(log *slog.Logger, sources core.SourceRepository, identities core.IdentityRepository, clients map[core.Forge]core.ForgeClient) *Sink {
+	return &Sink{
+		log:        log,
+		sources:    sources,
+		identities: identities,
+		clients:    clients,
+	}
+}
+
+// Send requests the assigned reviewer and removes the unassigned one. Users without
+// a login on the forge are skipped, so are requests the forge rejects permanently.
+func (s *Sink) Send(ctx context.Context, event core.Event) error {
+	var requested, removed string
+	switch event.Type {
+	case core.EventReviewerAssigned:
+		requested = event.UserID
+	case core.EventReviewerReassigned:
+		requested, removed = event.UserID, event.OldUserID
+	case core.EventReviewerRemoved:
+		removed = event.UserID
+	default:
+		return nil
+	}
+
+	source, err := s.sources.GetPRSource(ctx, event.PRID)
+	if err != nil {
+		// sources are stored with the PR, so PRs without one were created through the API
+		if errors.Is(err, core.ErrPRSourceNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get PR source: %w", err)
+	}
+	client, ok := s.clients[source.Forge]
+	if !ok {
+		return nil
+	}
+
+	if requested != "" {
+		if err := s.sync(ctx, *source, requested, client.RequestReviewers); err != nil {
+			return fmt.Errorf("request reviewer %s: %w", requested, err)
+		}
+	}
+	if removed != "" {
+		if err := s.sync(ctx, *source, removed, client.RemoveReviewers); err != nil {
+			return fmt.Errorf("remove reviewer %s: %w", removed, err)
+		}
+	}
+	return nil
+}
+
+type reviewersCall func(ctx context.Context, pr core.ForgePullRequest, logins []string) error
+
+func (s *Sink) sync(ctx context.Context, source core.ForgePullRequest, userID string, call reviewersCall) error {
+	identity, err := s.identities.GetIdentityByUser(ctx, source.Forge, userID)
+	if err != nil {
+		if errors.Is(err, core.ErrIdentityNotFound) {
+			s.log.Debug("user has no forge login, skipped", "forge", source.Forge, "user_id", userID)
+			return nil
+		}
+		return fmt.Errorf("get identity: %w", err)
+	}
+
+	err = call(ctx, source, []string{identity.Login})
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && !statusErr.Temporary() {
+		s.log.Warn("forge rejected reviewers change", "pr", source.PRID(), "login", identity.Login, "error", err)
+		return nil
+	}
+	return err
+}
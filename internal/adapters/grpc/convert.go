@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/grpc/reviewsv1"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+func toTeam(t *reviewsv1.Team) *core.Team {
+	team := core.Team{Name: t.GetTeamName()}
+
+	if p := t.GetPolicy(); p != nil {
+		team.Policy = core.ReviewPolicy{
+			RequiredRole:  core.UserRole(p.GetRequiredRole()),
+			RequiredCount: int(p.GetRequiredCount()),
+			MaxReviewers:  int(p.GetMaxReviewers()),
+		}
+	}
+
+	if sla := t.GetSla(); sla != nil {
+		team.SLA = core.ReviewSLA{
+			RemindAfter:   sla.GetRemindAfter().AsDuration(),
+			EscalateAfter: sla.GetEscalateAfter().AsDuration(),
+			Escalation:    core.EscalationAction(sla.GetEscalation()),
+		}
+	}
+
+	for _, m := range t.GetMembers() {
+		team.Members = append(team.Members, core.User{
+			ID:       m.GetUserId(),
+			Username: m.GetUsername(),
+			TeamName: team.Name,
+			IsActive: m.GetIsActive(),
+			Role:     core.UserRole(m.GetRole()),
+			Email:    m.GetEmail(),
+		})
+	}
+	return &team
+}
+
+func toTeamMessage(t *core.Team) *reviewsv1.Team {
+	msg := &reviewsv1.Team{
+		TeamName: t.Name,
+		Policy: &reviewsv1.ReviewPolicy{
+			RequiredRole:  string(t.Policy.RequiredRole),
+			RequiredCount: int32(t.Policy.RequiredCount),
+			MaxReviewers:  int32(t.Policy.MaxReviewers),
+		},
+	}
+
+	if t.SLA != (core.ReviewSLA{}) {
+		msg.Sla = &reviewsv1.ReviewSLA{Escalation: string(t.SLA.Escalation)}
+		if t.SLA.RemindAfter > 0 {
+			msg.Sla.RemindAfter = durationpb.New(t.SLA.RemindAfter)
+		}
+		if t.SLA.EscalateAfter > 0 {
+			msg.Sla.EscalateAfter = durationpb.New(t.SLA.EscalateAfter)
+		}
+	}
+
+	for _, m := range t.Members {
+		msg.Members = append(msg.Members, &reviewsv1.TeamMember{
+			UserId:   m.ID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+			Role:     string(m.Role),
+			Email:    m.Email,
+		})
+	}
+	return msg
+}
+
+// validateTeam mirrors the checks of the REST adapter, it returns an empty message for a valid team.
+func validateTeam(t *reviewsv1.Team) string {
+	if t.GetTeamName() == "" {
+		return "team_name is required"
+	}
+	if p := t.GetPolicy(); p != nil {
+		if role := core.UserRole(p.GetRequiredRole()); role != "" && !role.IsValid() {
+			return "policy.required_role is invalid"
+		}
+		if p.GetRequiredCount() < 0 {
+			return "policy.required_count must not be negative"
+		}
+		if p.GetMaxReviewers() < 0 {
+			return "policy.max_reviewers must not be negative"
+		}
+	}
+	if sla := t.GetSla(); sla != nil {
+		remindAfter, err := slaDuration(sla.GetRemindAfter())
+		if err != nil {
+			return "sla.remind_after is invalid"
+		}
+		escalateAfter, err := slaDuration(sla.GetEscalateAfter())
+		if err != nil {
+			return "sla.escalate_after is invalid"
+		}
+		if remindAfter > 0 && escalateAfter > 0 && escalateAfter <= remindAfter {
+			return "sla.escalate_after must be greater than sla.remind_after"
+		}
+		if escalation := core.EscalationAction(sla.GetEscalation()); escalation != "" && !escalation.IsValid() {
+			return "sla.escalation is invalid"
+		}
+	}
+	for _, m := range t.GetMembers() {
+		if m.GetUserId() == "" {
+			return "members.user_id is required"
+		}
+		if role := core.UserRole(m.GetRole()); role != "" && !role.IsValid() {
+			return "invalid role of user " + m.GetUserId()
+		}
+		if m.GetEmail() != "" && !core.IsValidEmail(m.GetEmail()) {
+			return "invalid email of user " + m.GetUserId()
+		}
+	}
+	return ""
+}
+
+// slaDuration checks an optional SLA duration, unset means the default.
+func slaDuration(d *durationpb.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+	if err := d.CheckValid(); err != nil {
+		return 0, err
+	}
+	if d.AsDuration() < 0 {
+		return 0, errNegativeDuration
+	}
+	return d.AsDuration(), nil
+}
+
+func toUserMessage(u *core.User) *reviewsv1.User {
+	return &reviewsv1.User{
+		UserId:      u.ID,
+		Username:    u.Username,
+		TeamName:    u.TeamName,
+		IsActive:    u.IsActive,
+		Role:        string(u.Role),
+		Email:       u.Email,
+		EmailDigest: u.EmailDigest,
+	}
+}
+
+func toPullRequestMessage(pr *core.PullRequest) *reviewsv1.PullRequest {
+	msg := &reviewsv1.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            string(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+	}
+	if pr.CreatedAt != nil {
+		msg.CreatedAt = timestamppb.New(*pr.CreatedAt)
+	}
+	if pr.MergedAt != nil {
+		msg.MergedAt = timestamppb.New(*pr.MergedAt)
+	}
+	return msg
+}
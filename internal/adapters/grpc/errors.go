@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+var errNegativeDuration = errors.New("negative duration")
+
+// toStatus maps core errors to gRPC statuses like toAPIError of the REST adapter.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, core.ErrTeamExists):
+		return status.Error(codes.AlreadyExists, "team_name already exists")
+	case errors.Is(err, core.ErrTeamNotFound),
+		errors.Is(err, core.ErrUserNotFound),
+		errors.Is(err, core.ErrUserNotActive),
+		errors.Is(err, core.ErrPRNotFound):
+		return status.Error(codes.NotFound, "resource not found")
+	case errors.Is(err, core.ErrPRExists):
+		return status.Error(codes.AlreadyExists, "PR id already exists")
+	case errors.Is(err, core.ErrPRMerged):
+		return status.Error(codes.FailedPrecondition, "cannot reassign on merged PR")
+	case errors.Is(err, core.ErrReviewerNotAssigned):
+		return status.Error(codes.FailedPrecondition, "reviewer is not assigned to this PR")
+	case errors.Is(err, core.ErrReviewerAssigned):
+		return status.Error(codes.AlreadyExists, "reviewer is already assigned to this PR")
+	case errors.Is(err, core.ErrNoCandidate):
+		return status.Error(codes.FailedPrecondition, "no active replacement candidate in team")
+	case errors.Is(err, core.ErrReviewerNotInTeam):
+		return status.Error(codes.FailedPrecondition, "reviewer is not a member of the team")
+	case errors.Is(err, core.ErrReviewerNotActive):
+		return status.Error(codes.FailedPrecondition, "reviewer is not active")
+	case errors.Is(err, core.ErrReviewerIsAuthor):
+		return status.Error(codes.InvalidArgument, "author cannot review own PR")
+	case errors.Is(err, core.ErrReviewerConflict):
+		return status.Error(codes.InvalidArgument, "reviewer is both requested and excluded")
+	case errors.Is(err, core.ErrTooManyReviewers):
+		return status.Error(codes.InvalidArgument, "too many reviewers requested")
+	case errors.Is(err, core.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, "invalid credentials")
+	case errors.Is(err, core.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "operation is not permitted")
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+func invalidArgument(message string) error {
+	return status.Error(codes.InvalidArgument, message)
+}
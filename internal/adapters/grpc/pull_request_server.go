@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/grpc/reviewsv1"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type pullRequestServer struct {
+	reviewsv1.UnimplementedPullRequestServiceServer
+	log *slog.Logger
+	prs core.PullRequestService
+}
+
+func (s *pullRequestServer) CreatePullRequest(ctx context.Context, req *reviewsv1.CreatePullRequestRequest) (*reviewsv1.CreatePullRequestResponse, error) {
+	switch {
+	case req.GetPullRequestId() == "":
+		return nil, invalidArgument("pull_request_id is required")
+	case req.GetPullRequestName() == "":
+		return nil, invalidArgument("pull_request_name is required")
+	case req.GetAuthorId() == "":
+		return nil, invalidArgument("author_id is required")
+	case slices.Contains(req.GetRequestedReviewers(), ""), slices.Contains(req.GetExcludedReviewers(), ""):
+		return nil, invalidArgument("reviewer ids must not be empty")
+	}
+
+	prefs := core.ReviewerPreferences{
+		Requested: req.GetRequestedReviewers(),
+		Excluded:  req.GetExcludedReviewers(),
+	}
+	pr, err := s.prs.CreatePR(ctx, req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId(), prefs)
+	if err != nil {
+		s.log.Error("create PR failed", "pr", req.GetPullRequestId(), "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.CreatePullRequestResponse{Pr: toPullRequestMessage(pr)}, nil
+}
+
+func (s *pullRequestServer) MergePullRequest(ctx context.Context, req *reviewsv1.MergePullRequestRequest) (*reviewsv1.MergePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+
+	pr, err := s.prs.MergePR(ctx, req.GetPullRequestId())
+	if err != nil {
+		s.log.Error("merge PR failed", "pr", req.GetPullRequestId(), "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.MergePullRequestResponse{Pr: toPullRequestMessage(pr)}, nil
+}
+
+func (s *pullRequestServer) ReassignReviewer(ctx context.Context, req *reviewsv1.ReassignReviewerRequest) (*reviewsv1.ReassignReviewerResponse, error) {
+	switch {
+	case req.GetPullRequestId() == "":
+		return nil, invalidArgument("pull_request_id is required")
+	case req.GetOldReviewerId() == "":
+		return nil, invalidArgument("old_reviewer_id is required")
+	}
+
+	reassignment, err := s.prs.ReassignReviewer(ctx, req.GetPullRequestId(), req.GetOldReviewerId(), req.GetNewUserId())
+	if err != nil {
+		s.log.Error("reassign reviewer failed", "pr", req.GetPullRequestId(), "old_reviewer_id", req.GetOldReviewerId(), "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.ReassignReviewerResponse{
+		Pr:         toPullRequestMessage(reassignment.PR),
+		ReplacedBy: reassignment.NewReviewerID,
+	}, nil
+}
+
+func (s *pullRequestServer) AddReviewer(ctx context.Context, req *reviewsv1.AddReviewerRequest) (*reviewsv1.AddReviewerResponse, error) {
+	if message := validateReviewerChange(req.GetPullRequestId(), req.GetReviewerId()); message != "" {
+		return nil, invalidArgument(message)
+	}
+
+	pr, err := s.prs.AddReviewer(ctx, req.GetPullRequestId(), req.GetReviewerId())
+	if err != nil {
+		s.log.Error("add reviewer failed", "pr", req.GetPullRequestId(), "reviewer_id", req.GetReviewerId(), "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.AddReviewerResponse{Pr: toPullRequestMessage(pr)}, nil
+}
+
+func (s *pullRequestServer) RemoveReviewer(ctx context.Context, req *reviewsv1.RemoveReviewerRequest) (*reviewsv1.RemoveReviewerResponse, error) {
+	if message := validateReviewerChange(req.GetPullRequestId(), req.GetReviewerId()); message != "" {
+		return nil, invalidArgument(message)
+	}
+
+	pr, err := s.prs.RemoveReviewer(ctx, req.GetPullRequestId(), req.GetReviewerId())
+	if err != nil {
+		s.log.Error("remove reviewer failed", "pr", req.GetPullRequestId(), "reviewer_id", req.GetReviewerId(), "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.RemoveReviewerResponse{Pr: toPullRequestMessage(pr)}, nil
+}
+
+func validateReviewerChange(prID, reviewerID string) string {
+	switch {
+	case prID == "":
+		return "pull_request_id is required"
+	case reviewerID == "":
+		return "reviewer_id is required"
+	}
+	return ""
+}
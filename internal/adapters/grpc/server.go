@@ -0,0 +1,99 @@
+// Package grpc serves the core services over gRPC, mirroring the REST adapter.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/grpc/reviewsv1"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// ActorMetadata carries the ID of the user performing the call, it is recorded in the audit log.
+const ActorMetadata = "x-actor-id"
+
+// TenantMetadata selects the tenant of the call, it may be omitted for the default tenant.
+const TenantMetadata = "x-tenant-id"
+
+// NewServer returns a gRPC server with the team, user, pull request and statistics services registered.
+// Calls are authenticated by authn unless it is nil.
+func NewServer(log *slog.Logger, authn core.Authenticator, ts core.TeamService, us core.UserService, prs core.PullRequestService, stats core.Statistics) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{withActor}
+	if authn != nil {
+		interceptors = append(interceptors, withAuth(log, authn))
+	}
+	interceptors = append(interceptors, withTenant)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+
+	reviewsv1.RegisterTeamServiceServer(server, &teamServer{log: log, ts: ts})
+	reviewsv1.RegisterUserServiceServer(server, &userServer{log: log, us: us})
+	reviewsv1.RegisterPullRequestServiceServer(server, &pullRequestServer{log: log, prs: prs})
+	reviewsv1.RegisterStatsServiceServer(server, &statsServer{log: log, stats: stats})
+	reflection.Register(server)
+
+	return server
+}
+
+func withActor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if values := metadata.ValueFromIncomingContext(ctx, ActorMetadata); len(values) > 0 && values[0] != "" {
+		ctx = core.WithActor(ctx, values[0])
+	}
+	return handler(ctx, req)
+}
+
+// withTenant scopes calls to the tenant in TenantMetadata like WithTenant of the REST adapter.
+func withTenant(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var tenantID string
+	if values := metadata.ValueFromIncomingContext(ctx, TenantMetadata); len(values) > 0 {
+		tenantID = values[0]
+	}
+	if principal, ok := core.PrincipalFromContext(ctx); ok && principal.TenantID != "" {
+		if tenantID != "" && tenantID != principal.TenantID {
+			return nil, status.Error(codes.PermissionDenied, "credentials do not grant access to the tenant")
+		}
+		tenantID = principal.TenantID
+	}
+	if tenantID == "" {
+		tenantID = core.DefaultTenant
+	}
+	if !core.IsValidTenantID(tenantID) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is invalid", TenantMetadata)
+	}
+	return handler(core.WithTenant(ctx, tenantID), req)
+}
+
+// withAuth authenticates calls by the bearer token of the authorization metadata like WithAuth
+// of the REST adapter. Reflection is a streaming service and stays public.
+func withAuth(log *slog.Logger, authn core.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var token string
+		if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+			token, _ = strings.CutPrefix(values[0], "Bearer ")
+		}
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "bearer token is required")
+		}
+
+		principal, err := authn.Authenticate(ctx, token)
+		if err != nil {
+			if !errors.Is(err, core.ErrInvalidCredentials) {
+				log.Error("authenticate failed", "error", err)
+			}
+			return nil, toStatus(err)
+		}
+
+		ctx = core.WithPrincipal(ctx, principal)
+		if principal.Role != core.AccessBot || core.ActorFromContext(ctx) == "" {
+			ctx = core.WithActor(ctx, principal.UserID)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,230 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/grpc/reviewsv1"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type fakeTeams struct {
+	core.TeamService
+	teams map[string]*core.Team
+}
+
+func (s *fakeTeams) CreateTeam(_ context.Context, team *core.Team) error {
+	if _, ok := s.teams[team.Name]; ok {
+		return core.ErrTeamExists
+	}
+	s.teams[team.Name] = team
+	return nil
+}
+
+func (s *fakeTeams) GetTeam(_ context.Context, teamName string) (*core.Team, error) {
+	team, ok := s.teams[teamName]
+	if !ok {
+		return nil, core.ErrTeamNotFound
+	}
+	return team, nil
+}
+
+type fakePullRequests struct {
+	core.PullRequestService
+	actorID  string
+	tenantID string
+}
+
+func (s *fakePullRequests) MergePR(ctx context.Context, prID string) (*core.PullRequest, error) {
+	s.actorID = core.ActorFromContext(ctx)
+	s.tenantID = core.TenantFromContext(ctx)
+	return &core.PullRequest{ID: prID, Status: core.StatusMerged}, nil
+}
+
+func dial(t *testing.T, server *grpc.Server) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestTeamService_MapsErrors(t *testing.T) {
+	teams := &fakeTeams{teams: make(map[string]*core.Team)}
+	conn := dial(t, NewServer(slog.New(slog.DiscardHandler), nil, teams, nil, nil, nil))
+	client := reviewsv1.NewTeamServiceClient(conn)
+	ctx := context.Background()
+
+	team := &reviewsv1.Team{
+		TeamName: "backend",
+		Sla:      &reviewsv1.ReviewSLA{RemindAfter: durationpb.New(24 * time.Hour), Escalation: "lead"},
+		Members:  []*reviewsv1.TeamMember{{UserId: "u1", Username: "Alice", IsActive: true}},
+	}
+	if _, err := client.CreateTeam(ctx, &reviewsv1.CreateTeamRequest{Team: team}); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	resp, err := client.GetTeam(ctx, &reviewsv1.GetTeamRequest{TeamName: "backend"})
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if got := resp.GetTeam().GetSla().GetRemindAfter().AsDuration(); got != 24*time.Hour {
+		t.Errorf("remind_after = %v, want 24h", got)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"exists", func() error {
+			_, err := client.CreateTeam(ctx, &reviewsv1.CreateTeamRequest{Team: team})
+			return err
+		}, codes.AlreadyExists},
+		{"not found", func() error {
+			_, err := client.GetTeam(ctx, &reviewsv1.GetTeamRequest{TeamName: "frontend"})
+			return err
+		}, codes.NotFound},
+		{"missing name", func() error {
+			_, err := client.GetTeam(ctx, &reviewsv1.GetTeamRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"invalid escalation", func() error {
+			_, err := client.CreateTeam(ctx, &reviewsv1.CreateTeamRequest{Team: &reviewsv1.Team{
+				TeamName: "qa",
+				Sla:      &reviewsv1.ReviewSLA{Escalation: "fire"},
+			}})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.want {
+				t.Errorf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_PassesActor(t *testing.T) {
+	prs := &fakePullRequests{}
+	conn := dial(t, NewServer(slog.New(slog.DiscardHandler), nil, nil, nil, prs, nil))
+	client := reviewsv1.NewPullRequestServiceClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), ActorMetadata, "u1")
+	resp, err := client.MergePullRequest(ctx, &reviewsv1.MergePullRequestRequest{PullRequestId: "pr-1"})
+	if err != nil {
+		t.Fatalf("merge PR: %v", err)
+	}
+	if resp.GetPr().GetStatus() != string(core.StatusMerged) {
+		t.Errorf("status = %q, want %q", resp.GetPr().GetStatus(), core.StatusMerged)
+	}
+	if prs.actorID != "u1" {
+		t.Errorf("actor = %q, want u1", prs.actorID)
+	}
+}
+
+type fakeAuthenticator map[string]core.Principal
+
+func (a fakeAuthenticator) Authenticate(_ context.Context, token string) (core.Principal, error) {
+	principal, ok := a[token]
+	if !ok {
+		return core.Principal{}, core.ErrInvalidCredentials
+	}
+	return principal, nil
+}
+
+func TestServer_Authenticates(t *testing.T) {
+	prs := &fakePullRequests{}
+	authn := fakeAuthenticator{
+		"member": {UserID: "u1", Role: core.AccessMember},
+		"bot":    {UserID: "ci", Role: core.AccessBot},
+		"admin":  {Role: core.AccessAdmin},
+	}
+	conn := dial(t, NewServer(slog.New(slog.DiscardHandler), authn, nil, nil, prs, nil))
+	client := reviewsv1.NewPullRequestServiceClient(conn)
+	req := &reviewsv1.MergePullRequestRequest{PullRequestId: "pr-1"}
+
+	cases := []struct {
+		name      string
+		md        []string
+		wantCode  codes.Code
+		wantActor string
+	}{
+		{"no token", nil, codes.Unauthenticated, ""},
+		{"unknown token", []string{"authorization", "Bearer other"}, codes.Unauthenticated, ""},
+		{"member acts as itself", []string{"authorization", "Bearer member", ActorMetadata, "u2"}, codes.OK, "u1"},
+		{"bot acts for user", []string{"authorization", "Bearer bot", ActorMetadata, "u2"}, codes.OK, "u2"},
+		{"bot acts as itself", []string{"authorization", "Bearer bot"}, codes.OK, "ci"},
+		{"admin token acts anonymously", []string{"authorization", "Bearer admin", ActorMetadata, "u2"}, codes.OK, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prs.actorID = ""
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tc.md...)
+			_, err := client.MergePullRequest(ctx, req)
+			if code := status.Code(err); code != tc.wantCode {
+				t.Fatalf("code = %v, want %v", code, tc.wantCode)
+			}
+			if prs.actorID != tc.wantActor {
+				t.Errorf("actor = %q, want %q", prs.actorID, tc.wantActor)
+			}
+		})
+	}
+}
+
+func TestServer_ScopesTenant(t *testing.T) {
+	prs := &fakePullRequests{}
+	authn := fakeAuthenticator{
+		"acme":  {UserID: "u1", Role: core.AccessMember, TenantID: "acme"},
+		"admin": {Role: core.AccessAdmin},
+	}
+	conn := dial(t, NewServer(slog.New(slog.DiscardHandler), authn, nil, nil, prs, nil))
+	client := reviewsv1.NewPullRequestServiceClient(conn)
+	req := &reviewsv1.MergePullRequestRequest{PullRequestId: "pr-1"}
+
+	cases := []struct {
+		name       string
+		md         []string
+		wantCode   codes.Code
+		wantTenant string
+	}{
+		{"bound token", []string{"authorization", "Bearer acme"}, codes.OK, "acme"},
+		{"bound token same tenant", []string{"authorization", "Bearer acme", TenantMetadata, "acme"}, codes.OK, "acme"},
+		{"bound token other tenant", []string{"authorization", "Bearer acme", TenantMetadata, "globex"}, codes.PermissionDenied, ""},
+		{"admin default tenant", []string{"authorization", "Bearer admin"}, codes.OK, core.DefaultTenant},
+		{"admin any tenant", []string{"authorization", "Bearer admin", TenantMetadata, "globex"}, codes.OK, "globex"},
+		{"invalid tenant", []string{"authorization", "Bearer admin", TenantMetadata, "Globex Corp"}, codes.InvalidArgument, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prs.tenantID = ""
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tc.md...)
+			_, err := client.MergePullRequest(ctx, req)
+			if code := status.Code(err); code != tc.wantCode {
+				t.Fatalf("code = %v, want %v", code, tc.wantCode)
+			}
+			if prs.tenantID != tc.wantTenant {
+				t.Errorf("tenant = %q, want %q", prs.tenantID, tc.wantTenant)
+			}
+		})
+	}
+}
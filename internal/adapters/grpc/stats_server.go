@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/grpc/reviewsv1"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type statsServer struct {
+	reviewsv1.UnimplementedStatsServiceServer
+	log   *slog.Logger
+	stats core.Statistics
+}
+
+func (s *statsServer) GetUserAssignmentStats(ctx context.Context, _ *reviewsv1.GetUserAssignmentStatsRequest) (*reviewsv1.GetUserAssignmentStatsResponse, error) {
+	stats, err := s.stats.GetUserAssignmentStats(ctx)
+	if err != nil {
+		s.log.Error("get user assignment stats failed", "error", err)
+		return nil, toStatus(err)
+	}
+
+	resp := &reviewsv1.GetUserAssignmentStatsResponse{UserAssignments: make(map[string]int32, len(stats))}
+	for userID, count := range stats {
+		resp.UserAssignments[userID] = int32(count)
+	}
+	return resp, nil
+}
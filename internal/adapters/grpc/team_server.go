@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/grpc/reviewsv1"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type teamServer struct {
+	reviewsv1.UnimplementedTeamServiceServer
+	log *slog.Logger
+	ts  core.TeamService
+}
+
+func (s *teamServer) CreateTeam(ctx context.Context, req *reviewsv1.CreateTeamRequest) (*reviewsv1.CreateTeamResponse, error) {
+	if message := validateTeam(req.GetTeam()); message != "" {
+		return nil, invalidArgument(message)
+	}
+
+	team := toTeam(req.GetTeam())
+	if err := s.ts.CreateTeam(ctx, team); err != nil {
+		s.log.Error("create team", "team", team.Name, "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.CreateTeamResponse{Team: toTeamMessage(team)}, nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *reviewsv1.GetTeamRequest) (*reviewsv1.GetTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	team, err := s.ts.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		s.log.Error("get team", "team", req.GetTeamName(), "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.GetTeamResponse{Team: toTeamMessage(team)}, nil
+}
@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/grpc/reviewsv1"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type userServer struct {
+	reviewsv1.UnimplementedUserServiceServer
+	log *slog.Logger
+	us  core.UserService
+}
+
+func (s *userServer) SetUserActive(ctx context.Context, req *reviewsv1.SetUserActiveRequest) (*reviewsv1.SetUserActiveResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.us.SetUserActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		s.log.Error("set user active failed", "user", req.GetUserId(), "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.SetUserActiveResponse{User: toUserMessage(user)}, nil
+}
+
+func (s *userServer) GetUserReviews(ctx context.Context, req *reviewsv1.GetUserReviewsRequest) (*reviewsv1.GetUserReviewsResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	prs, err := s.us.GetUserReviewRequests(ctx, req.GetUserId())
+	if err != nil {
+		s.log.Error("get user review requests failed", "user", req.GetUserId(), "error", err)
+		return nil, toStatus(err)
+	}
+
+	resp := &reviewsv1.GetUserReviewsResponse{UserId: req.GetUserId()}
+	for _, pr := range prs {
+		resp.PullRequests = append(resp.PullRequests, toPullRequestMessage(pr))
+	}
+	return resp, nil
+}
+
+func (s *userServer) SetEmailDigest(ctx context.Context, req *reviewsv1.SetEmailDigestRequest) (*reviewsv1.SetEmailDigestResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.us.SetEmailDigest(ctx, req.GetUserId(), req.GetEnabled())
+	if err != nil {
+		s.log.Error("set email digest failed", "user", req.GetUserId(), "error", err)
+		return nil, toStatus(err)
+	}
+	return &reviewsv1.SetEmailDigestResponse{User: toUserMessage(user)}, nil
+}
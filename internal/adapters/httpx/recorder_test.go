@@ -0,0 +1,44 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusRecorder_KeepsFirstStatus(t *testing.T) {
+	recorder := NewStatusRecorder(httptest.NewRecorder())
+	if recorder.Status() != http.StatusOK {
+		t.Errorf("status = %d, want %d", recorder.Status(), http.StatusOK)
+	}
+
+	recorder.WriteHeader(http.StatusCreated)
+	recorder.WriteHeader(http.StatusInternalServerError)
+	if recorder.Status() != http.StatusCreated {
+		t.Errorf("status = %d, want %d", recorder.Status(), http.StatusCreated)
+	}
+}
+
+func TestStatusRecorder_IgnoresStatusAfterWrite(t *testing.T) {
+	recorder := NewStatusRecorder(httptest.NewRecorder())
+	if _, err := recorder.Write([]byte("ok")); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder.WriteHeader(http.StatusInternalServerError)
+	if recorder.Status() != http.StatusOK {
+		t.Errorf("status = %d, want %d", recorder.Status(), http.StatusOK)
+	}
+}
+
+func TestStatusRecorder_Flushes(t *testing.T) {
+	w := httptest.NewRecorder()
+	recorder := NewStatusRecorder(w)
+
+	if err := http.NewResponseController(recorder).Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if !w.Flushed {
+		t.Error("underlying writer was not flushed")
+	}
+}
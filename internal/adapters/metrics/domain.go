@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// pullRequestRepository counts events committed together with PR changes,
+// so repeated merges and failed writes are not counted.
+type pullRequestRepository struct {
+	core.PullRequestRepository
+	m *Metrics
+}
+
+// WrapPullRequestRepository returns repo counting the review events it stores.
+func (m *Metrics) WrapPullRequestRepository(repo core.PullRequestRepository) core.PullRequestRepository {
+	return &pullRequestRepository{PullRequestRepository: repo, m: m}
+}
+
+func (r *pullRequestRepository) count(events []core.Event, err error) error {
+	if err == nil {
+		for _, e := range events {
+			r.m.events.WithLabelValues(string(e.Type)).Inc()
+		}
+	}
+	return err
+}
+
+func (r *pullRequestRepository) CreatePR(ctx context.Context, pr *core.PullRequest, source *core.ForgePullRequest, events []core.Event) error {
+	return r.count(events, r.PullRequestRepository.CreatePR(ctx, pr, source, events))
+}
+
+func (r *pullRequestRepository) UpdatePR(ctx context.Context, pr *core.PullRequest, events []core.Event) error {
+	return r.count(events, r.PullRequestRepository.UpdatePR(ctx, pr, events))
+}
+
+func (r *pullRequestRepository) AddReviewer(ctx context.Context, prID, userID, assignedBy string, maxReviewers int, events []core.Event) error {
+	return r.count(events, r.PullRequestRepository.AddReviewer(ctx, prID, userID, assignedBy, maxReviewers, events))
+}
+
+func (r *pullRequestRepository) RemoveReviewer(ctx context.Context, prID, userID string, events []core.Event) error {
+	return r.count(events, r.PullRequestRepository.RemoveReviewer(ctx, prID, userID, events))
+}
+
+func (r *pullRequestRepository) MarkReminded(ctx context.Context, prID, userID string, events []core.Event) (bool, error) {
+	marked, err := r.PullRequestRepository.MarkReminded(ctx, prID, userID, events)
+	if !marked {
+		return false, err
+	}
+	return true, r.count(events, err)
+}
+
+func (r *pullRequestRepository) MarkEscalated(ctx context.Context, prID, userID string, events []core.Event) (bool, error) {
+	marked, err := r.PullRequestRepository.MarkEscalated(ctx, prID, userID, events)
+	if !marked {
+		return false, err
+	}
+	return true, r.count(events, err)
+}
+
+// pullRequestService counts reassignments failing with core.ErrNoCandidate, which store no event.
+type pullRequestService struct {
+	core.PullRequestService
+	m *Metrics
+}
+
+// WrapPullRequestService returns prs counting reassignments that found no replacement.
+func (m *Metrics) WrapPullRequestService(prs core.PullRequestService) core.PullRequestService {
+	return &pullRequestService{PullRequestService: prs, m: m}
+}
+
+func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) (*core.ReviewReassignment, error) {
+	reassignment, err := s.PullRequestService.ReassignReviewer(ctx, prID, oldUserID, newUserID)
+	if errors.Is(err, core.ErrNoCandidate) {
+		s.m.noCandidate.Inc()
+	}
+	return reassignment, err
+}
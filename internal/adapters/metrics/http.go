@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/httpx"
+)
+
+// unmatchedRoute labels requests no route of the mux matches, so unknown paths do not add series.
+const unmatchedRoute = "unmatched"
+
+// WithHTTPMetrics counts requests and their latency by the mux pattern they are routed to.
+// It may wrap middlewares around the mux, requests they reject are counted too.
+func (m *Metrics) WithHTTPMetrics(mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := unmatchedRoute
+			if _, pattern := mux.Handler(r); pattern != "" {
+				route = pattern
+			}
+
+			start := time.Now()
+			recorder := httpx.NewStatusRecorder(w)
+			next.ServeHTTP(recorder, r)
+
+			status := strconv.Itoa(recorder.Status())
+			m.httpRequests.WithLabelValues(route, r.Method, status).Inc()
+			m.httpDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+		})
+	}
+}
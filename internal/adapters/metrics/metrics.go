@@ -0,0 +1,93 @@
+// Package metrics exposes Prometheus metrics of the HTTP API, the database and the review domain.
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+const namespace = "pr_reviews"
+
+// Metrics owns a registry with the collectors of the service, it is not the global one.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	dbDuration   *prometheus.HistogramVec
+
+	events      *prometheus.CounterVec
+	noCandidate prometheus.Counter
+}
+
+// New registers runtime, HTTP, database and domain metrics.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of HTTP requests by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duration of database statements by operation and outcome.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "status"}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "review_events_total",
+			Help:      "Committed review events by type, e.g. pr.created, pr.merged and reviewer.reassigned.",
+		}, []string{"type"}),
+		noCandidate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Reassignments rejected with NO_CANDIDATE.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.dbDuration,
+		m.events, m.noCandidate,
+	)
+	return m
+}
+
+// RegisterOpenReviews reports open reviews per user, read from prRepo on every scrape.
+func (m *Metrics) RegisterOpenReviews(log *slog.Logger, prRepo core.PullRequestRepository) {
+	m.registry.MustRegister(newOpenReviews(log, prRepo))
+}
+
+// Handler serves the metrics in the Prometheus exposition format. A failing collector
+// is reported in the log and the other metrics are still served.
+func (m *Metrics) Handler(log *slog.Logger) http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog:      slog.NewLogLogger(log.Handler(), slog.LevelError),
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// ObserveQuery records a database statement, it is the query observer of the db adapter.
+func (m *Metrics) ObserveQuery(operation string, duration time.Duration, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	m.dbDuration.WithLabelValues(operation, status).Observe(duration.Seconds())
+}
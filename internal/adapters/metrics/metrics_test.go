@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+func TestWithHTTPMetrics_LabelsRoutes(t *testing.T) {
+	m := New()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /team/get", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	handler := m.WithHTTPMetrics(mux)(mux)
+
+	for _, target := range []string{"/team/get?team_name=a", "/team/get?team_name=b", "/unknown/1"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	if got := testutil.ToFloat64(m.httpRequests.WithLabelValues("GET /team/get", "GET", "404")); got != 2 {
+		t.Errorf("requests of /team/get = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.httpRequests.WithLabelValues(unmatchedRoute, "GET", "404")); got != 1 {
+		t.Errorf("unmatched requests = %v, want 1", got)
+	}
+}
+
+type fakePRRepo struct {
+	core.PullRequestRepository
+	err         error
+	assignments []core.ReviewAssignment
+}
+
+func (r *fakePRRepo) UpdatePR(context.Context, *core.PullRequest, []core.Event) error {
+	return r.err
+}
+
+func (r *fakePRRepo) GetOpenAssignments(context.Context) ([]core.ReviewAssignment, error) {
+	return r.assignments, r.err
+}
+
+func TestWrapPullRequestRepository_CountsCommittedEvents(t *testing.T) {
+	m := New()
+	fake := &fakePRRepo{}
+	repo := m.WrapPullRequestRepository(fake)
+	merged := []core.Event{{Type: core.EventPRMerged}}
+
+	if err := repo.UpdatePR(context.Background(), &core.PullRequest{}, merged); err != nil {
+		t.Fatalf("update PR: %v", err)
+	}
+	fake.err = errors.New("connection reset")
+	if err := repo.UpdatePR(context.Background(), &core.PullRequest{}, merged); err == nil {
+		t.Fatal("update PR: want error")
+	}
+
+	if got := testutil.ToFloat64(m.events.WithLabelValues(string(core.EventPRMerged))); got != 1 {
+		t.Errorf("merged events = %v, want 1", got)
+	}
+}
+
+type fakePRService struct {
+	core.PullRequestService
+}
+
+func (s *fakePRService) ReassignReviewer(context.Context, string, string, string) (*core.ReviewReassignment, error) {
+	return nil, core.ErrNoCandidate
+}
+
+func TestWrapPullRequestService_CountsNoCandidate(t *testing.T) {
+	m := New()
+	prs := m.WrapPullRequestService(&fakePRService{})
+
+	if _, err := prs.ReassignReviewer(context.Background(), "pr-1", "u1", ""); !errors.Is(err, core.ErrNoCandidate) {
+		t.Fatalf("err = %v, want ErrNoCandidate", err)
+	}
+	if got := testutil.ToFloat64(m.noCandidate); got != 1 {
+		t.Errorf("no candidate = %v, want 1", got)
+	}
+}
+
+func TestOpenReviews(t *testing.T) {
+	m := New()
+	m.RegisterOpenReviews(slog.New(slog.DiscardHandler), &fakePRRepo{assignments: []core.ReviewAssignment{
+		{PRID: "pr-1", UserID: "u1", TenantID: "acme"},
+		{PRID: "pr-2", UserID: "u1", TenantID: "acme"},
+		{PRID: "pr-1", UserID: "u1", TenantID: "globex"},
+	}})
+
+	want := `
+# HELP pr_reviews_open_reviews Review assignments of open pull requests by tenant and reviewer.
+# TYPE pr_reviews_open_reviews gauge
+pr_reviews_open_reviews{tenant="acme",user_id="u1"} 2
+pr_reviews_open_reviews{tenant="globex",user_id="u1"} 1
+`
+	if err := testutil.GatherAndCompare(m.registry, strings.NewReader(want), "pr_reviews_open_reviews"); err != nil {
+		t.Error(err)
+	}
+}
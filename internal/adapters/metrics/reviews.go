@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// scrapeTimeout bounds the query of open reviews made on every scrape.
+const scrapeTimeout = 5 * time.Second
+
+// openReviews reports review assignments of open PRs per user, read from the database when scraped.
+type openReviews struct {
+	log    *slog.Logger
+	prRepo core.PullRequestRepository
+	desc   *prometheus.Desc
+}
+
+func newOpenReviews(log *slog.Logger, prRepo core.PullRequestRepository) *openReviews {
+	return &openReviews{
+		log:    log,
+		prRepo: prRepo,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "open_reviews"),
+			"Review assignments of open pull requests by tenant and reviewer.",
+			[]string{"tenant", "user_id"}, nil),
+	}
+}
+
+func (c *openReviews) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *openReviews) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	assignments, err := c.prRepo.GetOpenAssignments(ctx)
+	if err != nil {
+		c.log.Error("get open assignments failed", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	type reviewer struct{ tenantID, userID string }
+	counts := make(map[reviewer]int)
+	for _, a := range assignments {
+		counts[reviewer{a.TenantID, a.UserID}]++
+	}
+	for r, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), r.tenantID, r.userID)
+	}
+}
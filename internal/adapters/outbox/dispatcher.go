@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// Dispatcher drains the outbox to sinks. An entry is removed only after every
+// sink accepted its event, failed entries are retried with exponential backoff
+// to the sinks that did not accept it yet, so each sink receives every event
+// at least once. Entries failing MaxAttempts times are marked failed.
+type Dispatcher struct {
+	log   *slog.Logger
+	repo  core.OutboxRepository
+	sinks map[string]core.EventSink
+	cfg   config.OutboxConfig
+}
+
+func NewDispatcher(log *slog.Logger, repo core.OutboxRepository, sinks map[string]core.EventSink, cfg config.OutboxConfig) *Dispatcher {
+	return &Dispatcher{
+		log:   log,
+		repo:  repo,
+		sinks: sinks,
+		cfg:   cfg,
+	}
+}
+
+// Run polls the outbox until ctx is done. When ctx is done the batch
+// being dispatched is finished and one more batch is drained before Run returns,
+// remaining entries are delivered after restart.
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.log.Debug("outbox dispatcher started", "sinks", len(d.sinks))
+	defer d.log.Debug("outbox dispatcher stopped")
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep draining without a pause while batches are full
+		if d.dispatchBatch(ctx) == d.cfg.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			d.dispatchBatch(ctx)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchBatch returns the number of claimed entries.
+func (d *Dispatcher) dispatchBatch(ctx context.Context) int {
+	// entries already claimed are finished even if ctx is cancelled meanwhile
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.cfg.Lease)
+	defer cancel()
+
+	entries, err := d.repo.ClaimOutbox(sendCtx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		d.log.Error("claim outbox entries", "error", err)
+		return 0
+	}
+
+	for _, entry := range entries {
+		delivered, err := d.send(core.WithTenant(sendCtx, entry.Event.TenantID), entry)
+		if err != nil && entry.Attempts >= max(d.cfg.MaxAttempts, 1) {
+			d.log.Error("outbox event delivery failed, giving up", "event_id", entry.Event.ID, "attempt", entry.Attempts, "error", err)
+			if err := d.repo.FailOutbox(sendCtx, entry.ID, delivered, err.Error()); err != nil {
+				d.log.Error("fail outbox entry", "entry_id", entry.ID, "error", err)
+			}
+			continue
+		}
+		if err != nil {
+			delay := d.backoff(entry.Attempts)
+			d.log.Warn("outbox event delivery failed", "event_id", entry.Event.ID, "attempt", entry.Attempts, "retry_in", delay, "error", err)
+			if err := d.repo.RetryOutbox(sendCtx, entry.ID, delivered, delay, err.Error()); err != nil {
+				d.log.Error("reschedule outbox entry", "entry_id", entry.ID, "error", err)
+			}
+			continue
+		}
+
+		if err := d.repo.CompleteOutbox(sendCtx, entry.ID); err != nil {
+			d.log.Error("complete outbox entry", "entry_id", entry.ID, "error", err)
+		}
+	}
+	return len(entries)
+}
+
+// send passes the event of the entry to the sinks that did not accept it yet
+// and returns the names of all sinks that have accepted it.
+func (d *Dispatcher) send(ctx context.Context, entry core.OutboxEntry) ([]string, error) {
+	delivered := slices.Clone(entry.DeliveredSinks)
+	var errs []error
+	for name, sink := range d.sinks {
+		if slices.Contains(entry.DeliveredSinks, name) {
+			continue
+		}
+		if err := sink.Send(ctx, entry.Event); err != nil {
+			errs = append(errs, fmt.Errorf("sink %s: %w", name, err))
+			continue
+		}
+		delivered = append(delivered, name)
+	}
+	return delivered, errors.Join(errs...)
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.InitialBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
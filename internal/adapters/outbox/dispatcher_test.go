@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type fakeOutbox struct {
+	entries   []core.OutboxEntry
+	completed []int64
+	retried   map[int64]time.Duration
+	delivered map[int64][]string
+	failed    []int64
+}
+
+func (o *fakeOutbox) ClaimOutbox(_ context.Context, limit int, _ time.Duration) ([]core.OutboxEntry, error) {
+	n := min(limit, len(o.entries))
+	claimed := o.entries[:n]
+	o.entries = o.entries[n:]
+	return claimed, nil
+}
+
+func (o *fakeOutbox) CompleteOutbox(_ context.Context, entryID int64) error {
+	o.completed = append(o.completed, entryID)
+	return nil
+}
+
+func (o *fakeOutbox) RetryOutbox(_ context.Context, entryID int64, delivered []string, delay time.Duration, _ string) error {
+	o.retried[entryID] = delay
+	o.delivered[entryID] = delivered
+	return nil
+}
+
+func (o *fakeOutbox) FailOutbox(_ context.Context, entryID int64, delivered []string, _ string) error {
+	o.failed = append(o.failed, entryID)
+	o.delivered[entryID] = delivered
+	return nil
+}
+
+type sinkFunc func(ctx context.Context, event core.Event) error
+
+func (f sinkFunc) Send(ctx context.Context, event core.Event) error {
+	return f(ctx, event)
+}
+
+func TestDispatcher_CompletesOrRetries(t *testing.T) {
+	repo := &fakeOutbox{
+		entries: []core.OutboxEntry{
+			{ID: 1, Attempts: 1, Event: core.Event{ID: 10, Type: core.EventPRCreated}},
+			{ID: 2, Attempts: 3, Event: core.Event{ID: 11, Type: core.EventPRMerged}},
+		},
+		retried:   make(map[int64]time.Duration),
+		delivered: make(map[int64][]string),
+	}
+
+	var received []int64
+	sinks := map[string]core.EventSink{
+		"ok": sinkFunc(func(_ context.Context, e core.Event) error {
+			received = append(received, e.ID)
+			return nil
+		}),
+		"flaky": sinkFunc(func(_ context.Context, e core.Event) error {
+			if e.Type == core.EventPRMerged {
+				return errors.New("unavailable")
+			}
+			return nil
+		}),
+	}
+
+	cfg := config.OutboxConfig{
+		PollInterval:   time.Second,
+		BatchSize:      10,
+		Lease:          time.Minute,
+		InitialBackoff: time.Second,
+		MaxBackoff:     3 * time.Second,
+		MaxAttempts:    5,
+	}
+	d := NewDispatcher(slog.New(slog.DiscardHandler), repo, sinks, cfg)
+
+	if n := d.dispatchBatch(context.Background()); n != 2 {
+		t.Fatalf("dispatched %d entries, want 2", n)
+	}
+	if len(received) != 2 {
+		t.Errorf("healthy sink received %v, want both events", received)
+	}
+	if len(repo.completed) != 1 || repo.completed[0] != 1 {
+		t.Errorf("completed %v, want [1]", repo.completed)
+	}
+	if delay, ok := repo.retried[2]; !ok || delay != 3*time.Second {
+		t.Errorf("entry 2 retried in %v (%t), want capped backoff 3s", delay, ok)
+	}
+	if got := repo.delivered[2]; len(got) != 1 || got[0] != "ok" {
+		t.Errorf("entry 2 delivered to %v, want [ok]", got)
+	}
+}
+
+func TestDispatcher_RetriesFailedSinksUntilMaxAttempts(t *testing.T) {
+	repo := &fakeOutbox{
+		entries: []core.OutboxEntry{
+			{ID: 1, Attempts: 2, DeliveredSinks: []string{"ok"}, Event: core.Event{ID: 10}},
+			{ID: 2, Attempts: 3, DeliveredSinks: []string{"ok"}, Event: core.Event{ID: 11}},
+		},
+		retried:   make(map[int64]time.Duration),
+		delivered: make(map[int64][]string),
+	}
+
+	var received []int64
+	sinks := map[string]core.EventSink{
+		"ok": sinkFunc(func(_ context.Context, e core.Event) error {
+			received = append(received, e.ID)
+			return nil
+		}),
+		"broken": sinkFunc(func(context.Context, core.Event) error {
+			return errors.New("unavailable")
+		}),
+	}
+	cfg := config.OutboxConfig{BatchSize: 10, Lease: time.Minute, InitialBackoff: time.Second, MaxBackoff: time.Minute, MaxAttempts: 3}
+	d := NewDispatcher(slog.New(slog.DiscardHandler), repo, sinks, cfg)
+
+	d.dispatchBatch(context.Background())
+
+	if len(received) != 0 {
+		t.Errorf("sink that accepted the events received them again: %v", received)
+	}
+	if _, ok := repo.retried[1]; !ok {
+		t.Error("entry 1 with attempts left is not retried")
+	}
+	if len(repo.failed) != 1 || repo.failed[0] != 2 {
+		t.Errorf("failed %v, want [2] out of attempts", repo.failed)
+	}
+	if got := repo.delivered[2]; len(got) != 1 || got[0] != "ok" {
+		t.Errorf("failed entry delivered to %v, want [ok]", got)
+	}
+}
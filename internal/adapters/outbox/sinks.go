@@ -0,0 +1,67 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/webhook"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// LogSink writes events to the service log.
+type LogSink struct {
+	log *slog.Logger
+}
+
+func NewLogSink(log *slog.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+func (s *LogSink) Send(_ context.Context, event core.Event) error {
+	s.log.Info("event",
+		"event_id", event.ID,
+		"type", event.Type,
+		"pr", event.PRID,
+		"team", event.TeamName,
+		"user_id", event.UserID,
+		"old_user_id", event.OldUserID,
+		"actor_id", event.ActorID,
+	)
+	return nil
+}
+
+// FileSink appends events to a file as JSON lines.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open events file: %w", err)
+	}
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Send(_ context.Context, event core.Event) error {
+	line, err := json.Marshal(webhook.ToPayload(event))
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
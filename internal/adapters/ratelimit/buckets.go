@@ -0,0 +1,83 @@
+// Package ratelimit keeps token buckets of API clients within the process.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// sweepInterval is how often buckets that refilled are dropped, a full bucket is the same as none.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   core.RateLimit
+}
+
+// Buckets is a RateLimiter whose buckets are lost on restart and not shared between instances.
+type Buckets struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+var _ core.RateLimiter = (*Buckets)(nil)
+
+func NewBuckets() *Buckets {
+	return &Buckets{
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (b *Buckets) Allow(_ context.Context, key string, limit core.RateLimit) (bool, time.Duration, error) {
+	now := b.now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Sub(b.lastSweep) >= sweepInterval {
+		b.sweep(now)
+	}
+
+	bkt, ok := b.buckets[key]
+	if !ok {
+		bkt = &bucket{tokens: float64(limit.Burst), updated: now, limit: limit}
+		b.buckets[key] = bkt
+	}
+	bkt.refill(now)
+	bkt.limit = limit
+
+	if bkt.tokens >= 1 {
+		bkt.tokens--
+		return true, 0, nil
+	}
+	if limit.Rate <= 0 {
+		return false, math.MaxInt64, nil
+	}
+	wait := time.Duration((1 - bkt.tokens) / limit.Rate * float64(time.Second))
+	return false, wait, nil
+}
+
+func (bkt *bucket) refill(now time.Time) {
+	elapsed := now.Sub(bkt.updated).Seconds()
+	bkt.tokens = math.Min(float64(bkt.limit.Burst), bkt.tokens+elapsed*bkt.limit.Rate)
+	bkt.updated = now
+}
+
+func (b *Buckets) sweep(now time.Time) {
+	for key, bkt := range b.buckets {
+		bkt.refill(now)
+		if bkt.tokens >= float64(bkt.limit.Burst) {
+			delete(b.buckets, key)
+		}
+	}
+	b.lastSweep = now
+}
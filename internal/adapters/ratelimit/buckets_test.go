@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type clock struct{ now time.Time }
+
+func (c *clock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestBuckets() (*Buckets, *clock) {
+	c := &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+	b := NewBuckets()
+	b.now = func() time.Time { return c.now }
+	return b, c
+}
+
+func TestBuckets_AllowsBurstThenRate(t *testing.T) {
+	b, c := newTestBuckets()
+	limit := core.RateLimit{Rate: 2, Burst: 3}
+	ctx := context.Background()
+
+	for i := range 3 {
+		if ok, _, _ := b.Allow(ctx, "a", limit); !ok {
+			t.Fatalf("call %d of the burst rejected", i+1)
+		}
+	}
+	ok, wait, _ := b.Allow(ctx, "a", limit)
+	if ok {
+		t.Fatal("call over the burst allowed")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("wait = %v, want 500ms", wait)
+	}
+
+	if ok, _, _ := b.Allow(ctx, "b", limit); !ok {
+		t.Error("another key shares the bucket")
+	}
+
+	c.advance(500 * time.Millisecond)
+	if ok, _, _ := b.Allow(ctx, "a", limit); !ok {
+		t.Error("call after refill rejected")
+	}
+	if ok, _, _ := b.Allow(ctx, "a", limit); ok {
+		t.Error("refill gave more than one token")
+	}
+}
+
+func TestBuckets_SweepsRefilledBuckets(t *testing.T) {
+	b, c := newTestBuckets()
+	limit := core.RateLimit{Rate: 1, Burst: 1}
+	ctx := context.Background()
+
+	_, _, _ = b.Allow(ctx, "idle", limit)
+	c.advance(sweepInterval)
+	_, _, _ = b.Allow(ctx, "busy", limit)
+
+	if _, ok := b.buckets["idle"]; ok {
+		t.Error("refilled bucket is kept")
+	}
+	if _, ok := b.buckets["busy"]; !ok {
+		t.Error("used bucket is dropped")
+	}
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type EventDto struct {
+	ID            int64          `json:"id"`
+	Type          core.EventType `json:"type"`
+	PullRequestID string         `json:"pull_request_id,omitempty"`
+	TeamName      string         `json:"team_name,omitempty"`
+	UserID        string         `json:"user_id,omitempty"`
+	OldUserID     string         `json:"old_user_id,omitempty"`
+	ActorID       string         `json:"actor_id,omitempty"`
+	CreatedAt     string         `json:"created_at"`
+}
+
+func ToEventDto(e core.Event) EventDto {
+	return EventDto{
+		ID:            e.ID,
+		Type:          e.Type,
+		PullRequestID: e.PRID,
+		TeamName:      e.TeamName,
+		UserID:        e.UserID,
+		OldUserID:     e.OldUserID,
+		ActorID:       e.ActorID,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toEventDtos(events []core.Event) []EventDto {
+	dtos := make([]EventDto, len(events))
+	for i, e := range events {
+		dtos[i] = ToEventDto(e)
+	}
+	return dtos
+}
+
+type PRHistoryResponse struct {
+	PullRequestID string     `json:"pull_request_id"`
+	Events        []EventDto `json:"events"`
+}
+
+type AuditResponse struct {
+	Events []EventDto `json:"events"`
+}
+
+func NewPRHistoryHandler(log *slog.Logger, as core.AuditService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prID := r.URL.Query().Get("pull_request_id")
+		if prID == "" {
+			log.Warn("pull_request_id parameter is required")
+			writeFieldError(w, r, "pull_request_id", "is required")
+			return
+		}
+
+		events, err := as.GetPRHistory(r.Context(), prID)
+		if err != nil {
+			log.Error("get PR history failed", "pr", prID, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := PRHistoryResponse{
+			PullRequestID: prID,
+			Events:        toEventDtos(events),
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
+
+func NewAuditHandler(log *slog.Logger, as core.AuditService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter := core.EventFilter{
+			Type:     core.EventType(query.Get("type")),
+			PRID:     query.Get("pull_request_id"),
+			TeamName: query.Get("team_name"),
+			UserID:   query.Get("user_id"),
+			ActorID:  query.Get("actor_id"),
+		}
+
+		var err error
+		if filter.Since, err = parseTimeParam(query.Get("since")); err != nil {
+			writeFieldError(w, r, "since", "must be RFC3339 time")
+			return
+		}
+		if filter.Until, err = parseTimeParam(query.Get("until")); err != nil {
+			writeFieldError(w, r, "until", "must be RFC3339 time")
+			return
+		}
+
+		if value := query.Get("limit"); value != "" {
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit <= 0 {
+				writeFieldError(w, r, "limit", "must be a positive integer")
+				return
+			}
+			filter.Limit = limit
+		}
+
+		events, err := as.ListEvents(r.Context(), filter)
+		if err != nil {
+			log.Error("list audit events failed", "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := AuditResponse{
+			Events: toEventDtos(events),
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
+
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
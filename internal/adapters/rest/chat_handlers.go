@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type SetChatWebhookRequest struct {
+	TeamName string `json:"team_name"`
+	URL      string `json:"url"`
+}
+
+type SetChatWebhookResponse struct {
+	TeamName string `json:"team_name"`
+	URL      string `json:"url"`
+}
+
+// NewSetChatWebhookHandler sets the incoming webhook of the team chat, an empty url removes it.
+func NewSetChatWebhookHandler(log *slog.Logger, cs core.ChatService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SetChatWebhookRequest
+
+		if err := decodeJSON(r, &req); err != nil {
+			log.Warn("invalid request body", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		if req.TeamName == "" {
+			writeFieldError(w, r, "team_name", "is required")
+			return
+		}
+		if req.URL != "" {
+			u, err := url.Parse(req.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				writeFieldError(w, r, "url", "must be an absolute http(s) URL")
+				return
+			}
+		}
+
+		if err := cs.SetChatWebhook(r.Context(), req.TeamName, req.URL); err != nil {
+			log.Error("set chat webhook failed", "team", req.TeamName, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(SetChatWebhookResponse(req)); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/penkovgd/pr-reviews/internal/core"
 )
@@ -18,28 +21,131 @@ const (
 	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+	ErrorCodeValidation  ErrorCode = "VALIDATION_ERROR"
+	ErrorCodeInternal    ErrorCode = "INTERNAL"
+
+	ErrorCodeReviewerNotInTeam ErrorCode = "REVIEWER_NOT_IN_TEAM"
+	ErrorCodeReviewerInactive  ErrorCode = "REVIEWER_INACTIVE"
+	ErrorCodeReviewerIsAuthor  ErrorCode = "REVIEWER_IS_AUTHOR"
+	ErrorCodeReviewerConflict  ErrorCode = "REVIEWER_CONFLICT"
+	ErrorCodeTooManyReviewers  ErrorCode = "TOO_MANY_REVIEWERS"
+	ErrorCodeAlreadyAssigned   ErrorCode = "ALREADY_ASSIGNED"
+
+	ErrorCodeInvalidSignature  ErrorCode = "INVALID_SIGNATURE"
+	ErrorCodeIdentityNotMapped ErrorCode = "IDENTITY_NOT_MAPPED"
+
+	ErrorCodeIdempotencyKeyReused     ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrorCodeIdempotencyKeyInProgress ErrorCode = "IDEMPOTENCY_KEY_IN_PROGRESS"
+
+	ErrorCodeUnauthorized ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden    ErrorCode = "FORBIDDEN"
+	ErrorCodeRateLimited  ErrorCode = "RATE_LIMITED"
 )
 
 type ErrorResponse struct {
 	Error struct {
-		Code    ErrorCode `json:"code"`
-		Message string    `json:"message"`
+		Code    ErrorCode    `json:"code"`
+		Message string       `json:"message"`
+		Details []FieldError `json:"details,omitempty"`
 	} `json:"error"`
 }
 
-func writeAPIError(w http.ResponseWriter, status int, code ErrorCode, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 error document, Code and Details are extension members
+// carrying the same values as in ErrorResponse.
+type ProblemDetails struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     ErrorCode    `json:"code"`
+	Details  []FieldError `json:"details,omitempty"`
+}
+
+// FieldError tells what is wrong with a field of the request body or a request parameter.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
 
-	response := ErrorResponse{}
-	response.Error.Code = code
-	response.Error.Message = message
+func (e FieldError) String() string {
+	return e.Field + " " + e.Message
+}
+
+func writeAPIError(w http.ResponseWriter, r *http.Request, status int, code ErrorCode, message string) {
+	writeError(w, r, status, code, message, nil)
+}
+
+// writeValidationError answers 400 to a request with invalid fields.
+func writeValidationError(w http.ResponseWriter, r *http.Request, message string, details ...FieldError) {
+	writeError(w, r, http.StatusBadRequest, ErrorCodeValidation, message, details)
+}
+
+// writeFieldError answers 400 to a request with one invalid field.
+func writeFieldError(w http.ResponseWriter, r *http.Request, field, message string) {
+	fe := FieldError{Field: field, Message: message}
+	writeValidationError(w, r, fe.String(), fe)
+}
+
+// writeError answers with ErrorResponse, or with ProblemDetails to clients that accept it.
+func writeError(w http.ResponseWriter, r *http.Request, status int, code ErrorCode, message string, details []FieldError) {
+	var response any
+	if acceptsProblem(r) {
+		w.Header().Set("Content-Type", ProblemContentType)
+		response = ProblemDetails{
+			Type:     "about:blank",
+			Title:    http.StatusText(status),
+			Status:   status,
+			Detail:   message,
+			Instance: r.URL.Path,
+			Code:     code,
+			Details:  details,
+		}
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		errResp := ErrorResponse{}
+		errResp.Error.Code = code
+		errResp.Error.Message = message
+		errResp.Error.Details = details
+		response = errResp
+	}
+	w.WriteHeader(status)
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		slog.Error("encode json response", "error", err)
 	}
 }
 
+// acceptsProblem reports whether the Accept header of r prefers problem details to plain JSON.
+// Wildcards do not count, clients opt in by naming the media type.
+func acceptsProblem(r *http.Request) bool {
+	problemQ, jsonQ := 0.0, 0.0
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil {
+				continue
+			}
+			q := 1.0
+			if v, ok := params["q"]; ok {
+				if q, err = strconv.ParseFloat(v, 64); err != nil {
+					continue
+				}
+			}
+			switch mediaType {
+			case ProblemContentType:
+				problemQ = max(problemQ, q)
+			case "application/json":
+				jsonQ = max(jsonQ, q)
+			}
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
+
 func toAPIError(err error) (int, ErrorCode, string) {
 	switch {
 	case errors.Is(err, core.ErrTeamExists):
@@ -58,9 +164,29 @@ func toAPIError(err error) (int, ErrorCode, string) {
 		return http.StatusConflict, ErrorCodePRMerged, "cannot reassign on merged PR"
 	case errors.Is(err, core.ErrReviewerNotAssigned):
 		return http.StatusConflict, ErrorCodeNotAssigned, "reviewer is not assigned to this PR"
+	case errors.Is(err, core.ErrReviewerAssigned):
+		return http.StatusConflict, ErrorCodeAlreadyAssigned, "reviewer is already assigned to this PR"
+	case errors.Is(err, core.ErrWebhookNotFound):
+		return http.StatusNotFound, ErrorCodeNotFound, "resource not found"
+	case errors.Is(err, core.ErrIdentityNotFound):
+		return http.StatusUnprocessableEntity, ErrorCodeIdentityNotMapped, "forge login is not mapped to a user"
 	case errors.Is(err, core.ErrNoCandidate):
 		return http.StatusConflict, ErrorCodeNoCandidate, "no active replacement candidate in team"
+	case errors.Is(err, core.ErrReviewerNotInTeam):
+		return http.StatusConflict, ErrorCodeReviewerNotInTeam, "reviewer is not a member of the team"
+	case errors.Is(err, core.ErrReviewerNotActive):
+		return http.StatusConflict, ErrorCodeReviewerInactive, "reviewer is not active"
+	case errors.Is(err, core.ErrReviewerIsAuthor):
+		return http.StatusBadRequest, ErrorCodeReviewerIsAuthor, "author cannot review own PR"
+	case errors.Is(err, core.ErrReviewerConflict):
+		return http.StatusBadRequest, ErrorCodeReviewerConflict, "reviewer is both requested and excluded"
+	case errors.Is(err, core.ErrTooManyReviewers):
+		return http.StatusBadRequest, ErrorCodeTooManyReviewers, "too many reviewers requested"
+	case errors.Is(err, core.ErrInvalidCredentials):
+		return http.StatusUnauthorized, ErrorCodeUnauthorized, "invalid credentials"
+	case errors.Is(err, core.ErrForbidden):
+		return http.StatusForbidden, ErrorCodeForbidden, "operation is not permitted"
 	default:
-		return http.StatusInternalServerError, ErrorCodeNotFound, "internal server error"
+		return http.StatusInternalServerError, ErrorCodeInternal, "internal server error"
 	}
 }
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+const (
+	GiteaEventHeader     = "X-Gitea-Event"
+	GiteaSignatureHeader = "X-Gitea-Signature"
+)
+
+// NewGiteaWebhookHandler mirrors Gitea pull requests like NewGitHubWebhookHandler, Gitea sends
+// the payloads of GitHub signed by the plain hex HMAC-SHA256 in X-Gitea-Signature.
+func NewGiteaWebhookHandler(log *slog.Logger, secret string, is core.IntegrationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Warn("read gitea payload", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		if !verifyHMACSHA256(secret, body, r.Header.Get(GiteaSignatureHeader)) {
+			log.Warn("invalid gitea signature")
+			writeAPIError(w, r, http.StatusUnauthorized, ErrorCodeInvalidSignature, "invalid signature")
+			return
+		}
+
+		eventType := r.Header.Get(GiteaEventHeader)
+		if eventType != "pull_request" {
+			log.Debug("gitea event ignored", "event", eventType)
+			writeForgeEventResponse(log, w, forgeEventIgnored, nil)
+			return
+		}
+
+		var event GitHubPullRequestEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			log.Warn("invalid gitea payload", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		handlePullRequestEvent(log, w, r, is, core.ForgeGitea, event)
+	}
+}
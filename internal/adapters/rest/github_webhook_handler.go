@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+const (
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+)
+
+// GitHubPullRequestEvent holds the fields of a pull_request event payload the service uses.
+type GitHubPullRequestEvent struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// verifyGitHubSignature checks the HMAC-SHA256 of the raw body sent in X-Hub-Signature-256.
+func verifyGitHubSignature(secret string, body []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	return ok && verifyHMACSHA256(secret, body, digest)
+}
+
+// verifyHMACSHA256 checks the hex encoded HMAC-SHA256 of body.
+func verifyHMACSHA256(secret string, body []byte, digest string) bool {
+	got, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// NewGitHubWebhookHandler mirrors GitHub pull requests: opened and reopened ones are created,
+// merged ones are merged. Other events and actions are acknowledged and ignored.
+func NewGitHubWebhookHandler(log *slog.Logger, secret string, is core.IntegrationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Warn("read github payload", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		if !verifyGitHubSignature(secret, body, r.Header.Get(GitHubSignatureHeader)) {
+			log.Warn("invalid github signature")
+			writeAPIError(w, r, http.StatusUnauthorized, ErrorCodeInvalidSignature, "invalid signature")
+			return
+		}
+
+		eventType := r.Header.Get(GitHubEventHeader)
+		if eventType != "pull_request" {
+			log.Debug("github event ignored", "event", eventType)
+			writeForgeEventResponse(log, w, forgeEventIgnored, nil)
+			return
+		}
+
+		var event GitHubPullRequestEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			log.Warn("invalid github payload", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		handlePullRequestEvent(log, w, r, is, core.ForgeGitHub, event)
+	}
+}
+
+// handlePullRequestEvent mirrors pull requests of GitHub and the forges sending its payloads:
+// opened and reopened ones are created, merged ones are merged. Other actions are ignored.
+func handlePullRequestEvent(log *slog.Logger, w http.ResponseWriter, r *http.Request, is core.IntegrationService, forge core.Forge, event GitHubPullRequestEvent) {
+	source := core.ForgePullRequest{
+		Forge:      forge,
+		Repository: event.Repository.FullName,
+		Number:     event.PullRequest.Number,
+	}
+	if source.Repository == "" || source.Number <= 0 {
+		writeValidationError(w, r, "repository and pull request number are required")
+		return
+	}
+
+	var (
+		pr  *core.PullRequest
+		err error
+	)
+	switch {
+	case event.Action == "opened" || event.Action == "reopened":
+		pr, err = is.OpenPR(r.Context(), source, event.PullRequest.Title, event.PullRequest.User.Login)
+	case event.Action == "closed" && event.PullRequest.Merged:
+		pr, err = is.MergePR(r.Context(), source)
+		// PRs opened before the integration was set up are not mirrored
+		if errors.Is(err, core.ErrPRNotFound) {
+			log.Debug("merged PR is not mirrored", "forge", forge, "pr", source.PRID())
+			writeForgeEventResponse(log, w, forgeEventIgnored, nil)
+			return
+		}
+	default:
+		log.Debug("pull request action ignored", "forge", forge, "action", event.Action, "pr", source.PRID())
+		writeForgeEventResponse(log, w, forgeEventIgnored, nil)
+		return
+	}
+	if err != nil {
+		log.Error("handle pull request event failed", "forge", forge, "action", event.Action, "pr", source.PRID(), "error", err)
+
+		status, code, message := toAPIError(err)
+		writeAPIError(w, r, status, code, message)
+		return
+	}
+
+	writeForgeEventResponse(log, w, forgeEventProcessed, pr)
+}
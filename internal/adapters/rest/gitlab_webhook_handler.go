@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+const (
+	GitLabEventHeader = "X-Gitlab-Event"
+	GitLabTokenHeader = "X-Gitlab-Token"
+)
+
+// GitLabMergeRequestEvent holds the fields of a Merge Request Hook payload the service uses.
+type GitLabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	// User triggered the event, for open actions it is the author.
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+// NewGitLabWebhookHandler mirrors GitLab merge requests: opened and reopened ones are created,
+// merged ones are merged. Closed merge requests and other events are acknowledged and ignored.
+func NewGitLabWebhookHandler(log *slog.Logger, token string, is core.IntegrationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(GitLabTokenHeader)), []byte(token)) != 1 {
+			log.Warn("invalid gitlab token")
+			writeAPIError(w, r, http.StatusUnauthorized, ErrorCodeInvalidSignature, "invalid token")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Warn("read gitlab payload", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		eventType := r.Header.Get(GitLabEventHeader)
+		if eventType != "Merge Request Hook" {
+			log.Debug("gitlab event ignored", "event", eventType)
+			writeForgeEventResponse(log, w, forgeEventIgnored, nil)
+			return
+		}
+
+		var event GitLabMergeRequestEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			log.Warn("invalid gitlab payload", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		source := core.ForgePullRequest{
+			Forge:      core.ForgeGitLab,
+			Repository: event.Project.PathWithNamespace,
+			Number:     event.ObjectAttributes.IID,
+		}
+		if source.Repository == "" || source.Number <= 0 {
+			writeValidationError(w, r, "project and merge request iid are required")
+			return
+		}
+
+		var pr *core.PullRequest
+		switch action := event.ObjectAttributes.Action; action {
+		case "open", "reopen":
+			pr, err = is.OpenPR(r.Context(), source, event.ObjectAttributes.Title, event.User.Username)
+		case "merge":
+			pr, err = is.MergePR(r.Context(), source)
+			// merge requests opened before the integration was set up are not mirrored
+			if errors.Is(err, core.ErrPRNotFound) {
+				log.Debug("merged gitlab MR is not mirrored", "pr", source.PRID())
+				writeForgeEventResponse(log, w, forgeEventIgnored, nil)
+				return
+			}
+		default:
+			log.Debug("gitlab action ignored", "action", action, "pr", source.PRID())
+			writeForgeEventResponse(log, w, forgeEventIgnored, nil)
+			return
+		}
+		if err != nil {
+			log.Error("handle gitlab event failed", "action", event.ObjectAttributes.Action, "pr", source.PRID(), "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		writeForgeEventResponse(log, w, forgeEventProcessed, pr)
+	}
+}
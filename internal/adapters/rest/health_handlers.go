@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// readinessTimeout bounds the database checks, so a hanging connection fails the probe.
+const readinessTimeout = 2 * time.Second
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+type MigrationDto struct {
+	Version uint `json:"version"`
+	Latest  uint `json:"latest"`
+	Dirty   bool `json:"dirty"`
+}
+
+type ReadinessResponse struct {
+	Status    string        `json:"status"`
+	Draining  bool          `json:"draining"`
+	Database  string        `json:"database"`
+	Migration *MigrationDto `json:"migration,omitempty"`
+}
+
+// NewLivenessHandler answers as long as the server handles requests, it checks no dependencies.
+func NewLivenessHandler(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(log, w, http.StatusOK, LivenessResponse{Status: HealthStatusOK})
+	}
+}
+
+// NewReadinessHandler answers 503 while draining reports true, the database is unreachable
+// or its migrations are not applied cleanly.
+func NewReadinessHandler(log *slog.Logger, health core.HealthChecker, draining func() bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		response := ReadinessResponse{Status: HealthStatusOK, Draining: draining(), Database: HealthStatusOK}
+		if response.Draining {
+			response.Status = HealthStatusUnavailable
+		}
+		if err := health.Ping(ctx); err != nil {
+			log.Error("readiness ping failed", "error", err)
+			response.Status = HealthStatusUnavailable
+			response.Database = HealthStatusUnavailable
+		} else if migration, err := health.MigrationStatus(ctx); err != nil {
+			log.Error("readiness migration status failed", "error", err)
+			response.Status = HealthStatusUnavailable
+		} else {
+			response.Migration = &MigrationDto{Version: migration.Version, Latest: migration.Latest, Dirty: migration.Dirty}
+			if !migration.Migrated() {
+				response.Status = HealthStatusUnavailable
+			}
+		}
+
+		status := http.StatusOK
+		if response.Status != HealthStatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeHealth(log, w, status, response)
+	}
+}
+
+func writeHealth(log *slog.Logger, w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Error("encode response", "error", err)
+	}
+}
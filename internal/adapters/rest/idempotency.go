@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/httpx"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients retry a POST request without repeating its effect.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed for a repeated idempotency key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// WithIdempotency answers POST requests repeating an idempotency key of the client and route
+// within ttl with the response to the first one. A repeat with another body or arriving before
+// the first request is answered is rejected. Server errors release the key for retries, a key
+// whose response could not be stored stays claimed, so the request is never repeated.
+func WithIdempotency(log *slog.Logger, repo core.IdempotencyRepository, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				writeFieldError(w, r, IdempotencyKeyHeader, fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLength))
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeDecodeError(w, r, err)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			hash := sha256.Sum256(body)
+			requestHash := hex.EncodeToString(hash[:])
+			idempotencyKey := core.IdempotencyKey{
+				Client: tokenClient(r),
+				Key:    key,
+				Route:  r.Method + " " + r.URL.Path,
+			}
+
+			stored, reserved, err := repo.ReserveIdempotencyKey(r.Context(), idempotencyKey, requestHash, ttl)
+			if err != nil {
+				log.Error("reserve idempotency key failed", "key", key, "route", idempotencyKey.Route, "error", err)
+				writeAPIError(w, r, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
+				return
+			}
+			if !reserved {
+				replayResponse(log, w, r, stored, requestHash)
+				return
+			}
+
+			// the response is stored even if the client is gone, it is the one to retry
+			ctx := context.WithoutCancel(r.Context())
+			recorder := &responseRecorder{StatusRecorder: httpx.NewStatusRecorder(w)}
+			answered := false
+			defer func() {
+				if answered {
+					return
+				}
+				if err := repo.ReleaseIdempotencyKey(ctx, idempotencyKey); err != nil {
+					log.Error("release idempotency key failed", "key", key, "route", idempotencyKey.Route, "error", err)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+			if recorder.Status() >= http.StatusInternalServerError {
+				return
+			}
+			answered = true
+
+			response := &core.IdempotentResponse{
+				RequestHash: requestHash,
+				Status:      recorder.Status(),
+				ContentType: recorder.Header().Get("Content-Type"),
+				Body:        recorder.body.Bytes(),
+			}
+			if err := repo.SaveIdempotentResponse(ctx, idempotencyKey, response); err != nil {
+				log.Error("save idempotent response failed, key stays claimed", "key", key, "route", idempotencyKey.Route, "error", err)
+			}
+		})
+	}
+}
+
+func replayResponse(log *slog.Logger, w http.ResponseWriter, r *http.Request, stored *core.IdempotentResponse, requestHash string) {
+	switch {
+	case stored.RequestHash != requestHash:
+		writeAPIError(w, r, http.StatusUnprocessableEntity, ErrorCodeIdempotencyKeyReused, "idempotency key is already used with another request body")
+	case stored.Status == 0:
+		writeAPIError(w, r, http.StatusConflict, ErrorCodeIdempotencyKeyInProgress, "request with this idempotency key is in progress")
+	default:
+		w.Header().Set("Content-Type", stored.ContentType)
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(stored.Status)
+		if _, err := w.Write(stored.Body); err != nil {
+			log.Error("write replayed response", "error", err)
+		}
+	}
+}
+
+// responseRecorder copies the status and body of a response while writing it.
+type responseRecorder struct {
+	*httpx.StatusRecorder
+	body bytes.Buffer
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.body.Write(b)
+	return rec.StatusRecorder.Write(b)
+}
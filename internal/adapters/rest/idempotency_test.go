@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type fakeIdempotencyRepo struct {
+	core.IdempotencyRepository
+
+	mu        sync.Mutex
+	responses map[core.IdempotencyKey]*core.IdempotentResponse
+	saveErr   error
+}
+
+func (r *fakeIdempotencyRepo) ReserveIdempotencyKey(_ context.Context, key core.IdempotencyKey, requestHash string, _ time.Duration) (*core.IdempotentResponse, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.responses[key]; ok {
+		return stored, false, nil
+	}
+	r.responses[key] = &core.IdempotentResponse{RequestHash: requestHash}
+	return nil, true, nil
+}
+
+func (r *fakeIdempotencyRepo) SaveIdempotentResponse(_ context.Context, key core.IdempotencyKey, response *core.IdempotentResponse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.responses[key] = response
+	return nil
+}
+
+func (r *fakeIdempotencyRepo) ReleaseIdempotencyKey(_ context.Context, key core.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.responses, key)
+	return nil
+}
+
+// countingHandler creates a resource per call and answers with the number of calls.
+func countingHandler(calls *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		*calls++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(strings.Repeat("x", *calls)))
+	})
+}
+
+func idempotentRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(`{"pull_request_id":"pr-1"}`))
+	r.Header.Set(IdempotencyKeyHeader, "key-1")
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r.WithContext(core.WithPrincipal(r.Context(), core.Principal{UserID: token, Role: core.AccessMember}))
+}
+
+func TestWithIdempotency_ScopesKeysToClient(t *testing.T) {
+	repo := &fakeIdempotencyRepo{responses: map[core.IdempotencyKey]*core.IdempotentResponse{}}
+	var calls int
+	handler := WithIdempotency(slog.New(slog.DiscardHandler), repo, time.Hour)(countingHandler(&calls))
+
+	for _, token := range []string{"alice", "bob", "alice"} {
+		handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest(token))
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want once per client", calls)
+	}
+}
+
+func TestWithIdempotency_KeepsKeyWhenResponseIsNotSaved(t *testing.T) {
+	repo := &fakeIdempotencyRepo{
+		responses: map[core.IdempotencyKey]*core.IdempotentResponse{},
+		saveErr:   errors.New("connection reset"),
+	}
+	var calls int
+	handler := WithIdempotency(slog.New(slog.DiscardHandler), repo, time.Hour)(countingHandler(&calls))
+
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest("alice"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, idempotentRequest("alice"))
+
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+	if w.Code != http.StatusConflict {
+		t.Errorf("status of the retry = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type LinkIdentityRequest struct {
+	Forge  core.Forge `json:"forge"`
+	Login  string     `json:"login"`
+	UserID string     `json:"user_id"`
+}
+
+type IdentityDto struct {
+	Forge  core.Forge `json:"forge"`
+	Login  string     `json:"login"`
+	UserID string     `json:"user_id"`
+}
+
+type LinkIdentityResponse struct {
+	Identity IdentityDto `json:"identity"`
+}
+
+// ForgeEventResponse reports how an incoming forge event was handled.
+type ForgeEventResponse struct {
+	Status string          `json:"status"`
+	PR     *PullRequestDto `json:"pr,omitempty"`
+}
+
+const (
+	forgeEventProcessed = "processed"
+	forgeEventIgnored   = "ignored"
+)
+
+func writeForgeEventResponse(log *slog.Logger, w http.ResponseWriter, status string, pr *core.PullRequest) {
+	resp := ForgeEventResponse{Status: status}
+	if pr != nil {
+		dto := ToPullRequestDto(pr)
+		resp.PR = &dto
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Error("encode response", "error", err)
+	}
+}
+
+func NewLinkIdentityHandler(log *slog.Logger, is core.IntegrationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req LinkIdentityRequest
+
+		if err := decodeJSON(r, &req); err != nil {
+			log.Warn("invalid request body", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		if !req.Forge.IsValid() {
+			writeFieldError(w, r, "forge", "is unsupported")
+			return
+		}
+		if req.Login == "" {
+			writeFieldError(w, r, "login", "is required")
+			return
+		}
+		if req.UserID == "" {
+			writeFieldError(w, r, "user_id", "is required")
+			return
+		}
+
+		identity := &core.ForgeIdentity{Forge: req.Forge, Login: req.Login, UserID: req.UserID}
+		if err := is.LinkIdentity(r.Context(), identity); err != nil {
+			log.Error("link identity failed", "forge", req.Forge, "login", req.Login, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		resp := LinkIdentityResponse{Identity: IdentityDto(*identity)}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// NewMetricsHandler serves metrics, which cover all tenants, to admins only when authentication is enabled.
+func NewMetricsHandler(metrics http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if principal, ok := core.PrincipalFromContext(r.Context()); ok && principal.Role != core.AccessAdmin {
+			writeAPIError(w, r, http.StatusForbidden, ErrorCodeForbidden, "metrics are available to admins only")
+			return
+		}
+		metrics.ServeHTTP(w, r)
+	}
+}
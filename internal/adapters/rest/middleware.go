@@ -0,0 +1,228 @@
+package rest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// ActorHeader carries the ID of the user performing the request, it is recorded in the audit log.
+const ActorHeader = "X-Actor-ID"
+
+// WithActor takes the actor from ActorHeader, WithAuth replaces it unless the caller is a bot.
+func WithActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actorID := r.Header.Get(ActorHeader); actorID != "" {
+			r = r.WithContext(core.WithActor(r.Context(), actorID))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithAuth authenticates requests by the bearer token of the Authorization header and
+// rejects the others with 401, requests to public paths are passed through. The user
+// of the token becomes the actor, only bots may act on behalf of the user in ActorHeader.
+// Credentials not bound to a user act anonymously.
+func WithAuth(log *slog.Logger, authn core.Authenticator, publicPaths ...string) func(http.Handler) http.Handler {
+	public := make(map[string]bool, len(publicPaths))
+	for _, path := range publicPaths {
+		public[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if public[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeAPIError(w, r, http.StatusUnauthorized, ErrorCodeUnauthorized, "bearer token is required")
+				return
+			}
+			principal, err := authn.Authenticate(r.Context(), token)
+			if err != nil {
+				if !errors.Is(err, core.ErrInvalidCredentials) {
+					log.Error("authenticate failed", "error", err)
+				}
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				status, code, message := toAPIError(err)
+				writeAPIError(w, r, status, code, message)
+				return
+			}
+
+			ctx := core.WithPrincipal(r.Context(), principal)
+			if principal.Role != core.AccessBot || core.ActorFromContext(ctx) == "" {
+				ctx = core.WithActor(ctx, principal.UserID)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// tokenClient identifies an authenticated client by the digest of its token,
+// it is empty when authentication is disabled.
+func tokenClient(r *http.Request) string {
+	if _, ok := core.PrincipalFromContext(r.Context()); !ok {
+		return ""
+	}
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	digest := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(digest[:])
+}
+
+// TenantHeader selects the tenant of the request, it may be omitted for the default tenant.
+const TenantHeader = "X-Tenant-ID"
+
+// WithTenant scopes requests to the tenant in TenantHeader. Credentials bound to a tenant
+// always act in it and a conflicting header is rejected with 403.
+func WithTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID := r.Header.Get(TenantHeader)
+		if principal, ok := core.PrincipalFromContext(r.Context()); ok && principal.TenantID != "" {
+			if tenantID != "" && tenantID != principal.TenantID {
+				writeAPIError(w, r, http.StatusForbidden, ErrorCodeForbidden, "credentials do not grant access to the tenant")
+				return
+			}
+			tenantID = principal.TenantID
+		}
+		if tenantID == "" {
+			tenantID = core.DefaultTenant
+		}
+		if !core.IsValidTenantID(tenantID) {
+			writeFieldError(w, r, TenantHeader, "is invalid")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(core.WithTenant(r.Context(), tenantID)))
+	})
+}
+
+// WithValidation rejects requests that do not match the OpenAPI spec with 400,
+// requests to routes missing from the spec are passed through.
+func WithValidation(spec []byte) (func(http.Handler) http.Handler, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("load OpenAPI spec: %w", err)
+	}
+	// match paths whatever host the service is reached by
+	doc.Servers = nil
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("build OpenAPI router: %w", err)
+	}
+
+	options := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				writeRequestError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// writeRequestError answers a request the OpenAPI validation rejected,
+// naming the invalid field without the schema dumps of kin-openapi.
+func writeRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeDecodeError(w, r, err)
+		return
+	}
+
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		writeValidationError(w, r, "invalid request")
+		return
+	}
+
+	reason := reqErr.Reason
+	var field string
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		field, reason = schemaField(schemaErr)
+	} else if reqErr.Parameter != nil {
+		reason = "is invalid"
+	} else if reason == "" && reqErr.Err != nil {
+		reason = reqErr.Err.Error()
+	}
+
+	switch {
+	case reqErr.Parameter != nil:
+		writeFieldError(w, r, reqErr.Parameter.Name, reason)
+	case field != "":
+		writeFieldError(w, r, field, reason)
+	default:
+		writeValidationError(w, r, "invalid request body: "+reason)
+	}
+}
+
+// propertyReason matches reasons of kin-openapi about missing and unknown properties.
+var propertyReason = regexp.MustCompile(`^property "(.+)" is (missing|unsupported)$`)
+
+// schemaField returns the dotted path of the invalid field and what is wrong with it,
+// worded like the checks of the handlers.
+func schemaField(err *openapi3.SchemaError) (string, string) {
+	path := err.JSONPointer()
+	reason := strings.TrimPrefix(err.Reason, "value ")
+
+	if m := propertyReason.FindStringSubmatch(reason); m != nil {
+		if len(path) == 0 || path[len(path)-1] != m[1] {
+			path = append(path, m[1])
+		}
+		reason = "is required"
+		if m[2] == "unsupported" {
+			reason = "is unknown"
+		}
+	}
+	var field strings.Builder
+	for _, segment := range path {
+		if _, err := strconv.Atoi(segment); err == nil {
+			fmt.Fprintf(&field, "[%s]", segment)
+			continue
+		}
+		if field.Len() > 0 {
+			field.WriteByte('.')
+		}
+		field.WriteString(segment)
+	}
+	return field.String(), reason
+}
+
+// WithBodyLimit fails reads of request bodies longer than limit bytes, handlers answer them with 413.
+func WithBodyLimit(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package rest
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// NewOpenAPIHandler serves the OpenAPI spec of the service.
+func NewOpenAPIHandler(log *slog.Logger, spec []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		if _, err := w.Write(spec); err != nil {
+			log.Error("write OpenAPI spec", "error", err)
+		}
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"slices"
 
 	"github.com/penkovgd/pr-reviews/internal/core"
 )
 
 type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID      string   `json:"pull_request_id"`
+	PullRequestName    string   `json:"pull_request_name"`
+	AuthorID           string   `json:"author_id"`
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	ExcludedReviewers  []string `json:"excluded_reviewers,omitempty"`
 }
 
 type CreatePRResponse struct {
@@ -40,31 +43,44 @@ func NewCreatePRHandler(log *slog.Logger, prs core.PullRequestService) http.Hand
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req CreatePRRequest
 
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := decodeJSON(r, &req); err != nil {
 			log.Warn("invalid request body", "error", err)
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "invalid request body")
+			writeDecodeError(w, r, err)
 			return
 		}
 
 		if req.PullRequestID == "" {
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "pull_request_id is required")
+			writeFieldError(w, r, "pull_request_id", "is required")
 			return
 		}
 		if req.PullRequestName == "" {
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "pull_request_name is required")
+			writeFieldError(w, r, "pull_request_name", "is required")
 			return
 		}
 		if req.AuthorID == "" {
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "author_id is required")
+			writeFieldError(w, r, "author_id", "is required")
 			return
 		}
 
-		pr, err := prs.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+		if slices.Contains(req.RequestedReviewers, "") {
+			writeFieldError(w, r, "requested_reviewers", "must not contain empty ids")
+			return
+		}
+		if slices.Contains(req.ExcludedReviewers, "") {
+			writeFieldError(w, r, "excluded_reviewers", "must not contain empty ids")
+			return
+		}
+
+		prefs := core.ReviewerPreferences{
+			Requested: req.RequestedReviewers,
+			Excluded:  req.ExcludedReviewers,
+		}
+		pr, err := prs.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, prefs)
 		if err != nil {
 			log.Error("create PR failed", "pr", req.PullRequestID, "error", err)
 
 			status, code, message := toAPIError(err)
-			writeAPIError(w, status, code, message)
+			writeAPIError(w, r, status, code, message)
 			return
 		}
 
@@ -46,14 +46,14 @@ func NewMergePRHandler(log *slog.Logger, prs core.PullRequestService) http.Handl
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req MergePRRequest
 
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := decodeJSON(r, &req); err != nil {
 			log.Warn("invalid request body", "error", err)
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "invalid request body")
+			writeDecodeError(w, r, err)
 			return
 		}
 
 		if req.PullRequestID == "" {
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "pull_request_id is required")
+			writeFieldError(w, r, "pull_request_id", "is required")
 			return
 		}
 
@@ -62,7 +62,7 @@ func NewMergePRHandler(log *slog.Logger, prs core.PullRequestService) http.Handl
 			log.Error("merge PR failed", "pr", req.PullRequestID, "error", err)
 
 			status, code, message := toAPIError(err)
-			writeAPIError(w, status, code, message)
+			writeAPIError(w, r, status, code, message)
 			return
 		}
 
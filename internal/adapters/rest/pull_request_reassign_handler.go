@@ -11,6 +11,7 @@ import (
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_reviewer_id"`
+	NewUserID     string `json:"new_user_id,omitempty"`
 }
 
 type ReassignReviewerResponse struct {
@@ -22,27 +23,27 @@ func NewReassignReviewerHandler(log *slog.Logger, prs core.PullRequestService) h
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req ReassignReviewerRequest
 
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := decodeJSON(r, &req); err != nil {
 			log.Warn("invalid request body", "error", err)
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "invalid request body")
+			writeDecodeError(w, r, err)
 			return
 		}
 
 		if req.PullRequestID == "" {
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "pull_request_id is required")
+			writeFieldError(w, r, "pull_request_id", "is required")
 			return
 		}
 		if req.OldUserID == "" {
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "old_reviewer_id is required")
+			writeFieldError(w, r, "old_reviewer_id", "is required")
 			return
 		}
 
-		reassignment, err := prs.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+		reassignment, err := prs.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID, req.NewUserID)
 		if err != nil {
 			log.Error("reassign reviewer failed", "pr", req.PullRequestID, "old_reviewer_id", req.OldUserID, "error", err)
 
 			status, code, message := toAPIError(err)
-			writeAPIError(w, status, code, message)
+			writeAPIError(w, r, status, code, message)
 			return
 		}
 
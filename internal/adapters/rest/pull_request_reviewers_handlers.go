@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type ChangeReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+}
+
+type ChangeReviewerResponse struct {
+	PR PullRequestDto `json:"pr"`
+}
+
+type changeReviewerFunc func(r *http.Request, req ChangeReviewerRequest) (*core.PullRequest, error)
+
+func NewAddReviewerHandler(log *slog.Logger, prs core.PullRequestService) http.HandlerFunc {
+	return newChangeReviewerHandler(log, "add reviewer", func(r *http.Request, req ChangeReviewerRequest) (*core.PullRequest, error) {
+		return prs.AddReviewer(r.Context(), req.PullRequestID, req.ReviewerID)
+	})
+}
+
+func NewRemoveReviewerHandler(log *slog.Logger, prs core.PullRequestService) http.HandlerFunc {
+	return newChangeReviewerHandler(log, "remove reviewer", func(r *http.Request, req ChangeReviewerRequest) (*core.PullRequest, error) {
+		return prs.RemoveReviewer(r.Context(), req.PullRequestID, req.ReviewerID)
+	})
+}
+
+func newChangeReviewerHandler(log *slog.Logger, action string, change changeReviewerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ChangeReviewerRequest
+
+		if err := decodeJSON(r, &req); err != nil {
+			log.Warn("invalid request body", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		if req.PullRequestID == "" {
+			writeFieldError(w, r, "pull_request_id", "is required")
+			return
+		}
+		if req.ReviewerID == "" {
+			writeFieldError(w, r, "reviewer_id", "is required")
+			return
+		}
+
+		pr, err := change(r, req)
+		if err != nil {
+			log.Error(action+" failed", "pr", req.PullRequestID, "reviewer_id", req.ReviewerID, "actor_id", core.ActorFromContext(r.Context()), "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+		log.Info(action, "pr", req.PullRequestID, "reviewer_id", req.ReviewerID, "actor_id", core.ActorFromContext(r.Context()))
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := ChangeReviewerResponse{
+			PR: ToPullRequestDto(pr),
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
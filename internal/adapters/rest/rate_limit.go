@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// RateLimitGroup applies Limit to requests with one of the methods and path prefixes, empty lists match all.
+type RateLimitGroup struct {
+	Name         string
+	Methods      []string
+	PathPrefixes []string
+	Limit        core.RateLimit
+}
+
+func (g RateLimitGroup) matches(r *http.Request) bool {
+	if len(g.Methods) > 0 && !slices.Contains(g.Methods, r.Method) {
+		return false
+	}
+	if len(g.PathPrefixes) > 0 && !slices.ContainsFunc(g.PathPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(r.URL.Path, prefix)
+	}) {
+		return false
+	}
+	return true
+}
+
+type RateLimitPolicy struct {
+	// Groups are tried in order, Default applies to requests matching none of them.
+	Groups  []RateLimitGroup
+	Default core.RateLimit
+	// ClientIPHeader is the header set to the client IP by a proxy, the peer address is used if empty.
+	ClientIPHeader string
+}
+
+// WithRateLimit answers 429 with Retry-After to clients out of tokens for the group of the request.
+// Authenticated clients have buckets per token, anonymous ones per IP. If the limiter fails,
+// requests are let through, so are requests to exempt paths.
+func WithRateLimit(log *slog.Logger, limiter core.RateLimiter, policy RateLimitPolicy, exemptPaths ...string) func(http.Handler) http.Handler {
+	exempt := make(map[string]bool, len(exemptPaths))
+	for _, path := range exemptPaths {
+		exempt[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if exempt[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			group, limit := "default", policy.Default
+			for _, g := range policy.Groups {
+				if g.matches(r) {
+					group, limit = g.Name, g.Limit
+					break
+				}
+			}
+
+			key := group + ":" + rateLimitClient(r, policy.ClientIPHeader)
+			allowed, wait, err := limiter.Allow(r.Context(), key, limit)
+			if err != nil {
+				log.Error("rate limit failed", "group", group, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !allowed {
+				retryAfter := max(1, int64(math.Ceil(wait.Seconds())))
+				w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+				writeAPIError(w, r, http.StatusTooManyRequests, ErrorCodeRateLimited, "rate limit exceeded")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitClient identifies the client by the digest of its token once it is authenticated, by IP otherwise.
+func rateLimitClient(r *http.Request, clientIPHeader string) string {
+	if client := tokenClient(r); client != "" {
+		return client
+	}
+
+	if clientIPHeader != "" {
+		// X-Forwarded-For lists the client first
+		ip, _, _ := strings.Cut(r.Header.Get(clientIPHeader), ",")
+		if ip = strings.TrimSpace(ip); ip != "" {
+			return "ip:" + ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
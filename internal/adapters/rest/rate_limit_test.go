@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// exhaustedLimiter has no tokens left for any client.
+type exhaustedLimiter struct{}
+
+func (exhaustedLimiter) Allow(context.Context, string, core.RateLimit) (bool, time.Duration, error) {
+	return false, time.Second, nil
+}
+
+func TestWithRateLimit_ExemptsPaths(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	policy := RateLimitPolicy{Default: core.RateLimit{Rate: 1, Burst: 1}}
+	handler := WithRateLimit(slog.New(slog.DiscardHandler), exhaustedLimiter{}, policy, "/healthz", "/metrics")(next)
+
+	cases := []struct {
+		path       string
+		wantStatus int
+	}{
+		{"/healthz", http.StatusOK},
+		{"/metrics", http.StatusOK},
+		{"/team/get", http.StatusTooManyRequests},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if w.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+		})
+	}
+}
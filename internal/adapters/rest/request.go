@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// unknownFieldPrefix starts errors of json.Decoder on fields rejected by DisallowUnknownFields.
+const unknownFieldPrefix = "json: unknown field "
+
+// decodeJSON decodes the request body into v, fields missing from v are rejected.
+func decodeJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// writeDecodeError answers a request whose body could not be read or decoded.
+func writeDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	var unmarshalType *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeError(w, r, http.StatusRequestEntityTooLarge, ErrorCodeValidation, "request body is too large", nil)
+	case errors.As(err, &unmarshalType) && unmarshalType.Field != "":
+		writeFieldError(w, r, unmarshalType.Field, "must be "+jsonTypeName(unmarshalType.Type.Kind()))
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), unknownFieldPrefix))
+		writeFieldError(w, r, field, "is unknown")
+	default:
+		writeValidationError(w, r, "invalid request body")
+	}
+}
+
+// jsonTypeName names the JSON type a Go value of the kind is decoded from.
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// reviewStreamKeepAlive is the interval of comments keeping idle streams open through proxies.
+const reviewStreamKeepAlive = 15 * time.Second
+
+// NewReviewStreamHandler streams events changing review requests of the user as server-sent events.
+// Clients resume with the Last-Event-ID header or the last_event_id query parameter.
+func NewReviewStreamHandler(log *slog.Logger, us core.UserService, updates core.ReviewUpdates) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		userID := query.Get("user_id")
+		if userID == "" {
+			writeFieldError(w, r, "user_id", "is required")
+			return
+		}
+
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = query.Get("last_event_id")
+		}
+		var afterID int64
+		if lastEventID != "" {
+			var err error
+			afterID, err = strconv.ParseInt(lastEventID, 10, 64)
+			if err != nil || afterID < 0 {
+				writeFieldError(w, r, "last_event_id", "must be a non-negative integer")
+				return
+			}
+		}
+
+		// subscribe before the replay so that no event falls between them
+		events, unsubscribe := updates.Subscribe(core.TenantFromContext(r.Context()), userID)
+		defer unsubscribe()
+
+		replay, err := us.GetReviewEvents(r.Context(), userID, afterID)
+		if err != nil {
+			log.Error("get review events failed", "user", userID, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+
+		replayed := make(map[int64]struct{}, len(replay))
+		for _, e := range replay {
+			replayed[e.ID] = struct{}{}
+			if err := writeServerSentEvent(w, e); err != nil {
+				log.Warn("write review stream", "user", userID, "error", err)
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			log.Warn("flush review stream", "user", userID, "error", err)
+			return
+		}
+
+		keepAlive := time.NewTicker(reviewStreamKeepAlive)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case e, ok := <-events:
+				if !ok {
+					// dropped or shutting down, the client resumes from the last event it got
+					return
+				}
+				if _, ok := replayed[e.ID]; ok {
+					continue
+				}
+				if err := writeServerSentEvent(w, e); err != nil {
+					log.Warn("write review stream", "user", userID, "error", err)
+					return
+				}
+			case <-keepAlive.C:
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeServerSentEvent(w http.ResponseWriter, e core.Event) error {
+	data, err := json.Marshal(ToEventDto(e))
+	if err != nil {
+		return fmt.Errorf("encode event %d: %w", e.ID, err)
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
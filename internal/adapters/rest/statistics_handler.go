@@ -17,7 +17,7 @@ func NewUserAssignmentStatsHandler(log *slog.Logger, stats core.Statistics) http
 		stats, err := stats.GetUserAssignmentStats(r.Context())
 		if err != nil {
 			log.Error("get user assignment stats failed", "error", err)
-			writeAPIError(w, http.StatusInternalServerError, ErrorCodeNotFound, "internal server error")
+			writeAPIError(w, r, http.StatusInternalServerError, ErrorCodeInternal, "internal server error")
 			return
 		}
 
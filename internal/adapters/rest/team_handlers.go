@@ -2,50 +2,164 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/penkovgd/pr-reviews/internal/core"
 )
 
 type TeamDto struct {
-	TeamName string      `json:"team_name"`
-	Members  []MemberDto `json:"members"`
+	TeamName string           `json:"team_name"`
+	Policy   *ReviewPolicyDto `json:"policy,omitempty"`
+	SLA      *ReviewSLADto    `json:"sla,omitempty"`
+	Members  []MemberDto      `json:"members"`
+}
+
+type ReviewPolicyDto struct {
+	RequiredRole  core.UserRole `json:"required_role"`
+	RequiredCount int           `json:"required_count"`
+	MaxReviewers  int           `json:"max_reviewers"`
+}
+
+// ReviewSLADto holds durations in Go syntax, e.g. "48h", empty values fall back to the defaults.
+type ReviewSLADto struct {
+	RemindAfter   string                `json:"remind_after,omitempty"`
+	EscalateAfter string                `json:"escalate_after,omitempty"`
+	Escalation    core.EscalationAction `json:"escalation,omitempty"`
+}
+
+func parseSLADuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err == nil && d < 0 {
+		err = errors.New("negative duration")
+	}
+	return d, err
 }
 
 type MemberDto struct {
-	ID       string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	ID       string        `json:"user_id"`
+	Username string        `json:"username"`
+	IsActive bool          `json:"is_active"`
+	Role     core.UserRole `json:"role"`
+	Email    string        `json:"email,omitempty"`
 }
 
 func ToTeam(dto TeamDto) *core.Team {
 	t := core.Team{Name: dto.TeamName}
 
+	if dto.Policy != nil {
+		t.Policy = core.ReviewPolicy{
+			RequiredRole:  dto.Policy.RequiredRole,
+			RequiredCount: dto.Policy.RequiredCount,
+			MaxReviewers:  dto.Policy.MaxReviewers,
+		}
+	}
+
+	if dto.SLA != nil {
+		// validated by validateTeamDto
+		t.SLA.RemindAfter, _ = parseSLADuration(dto.SLA.RemindAfter)
+		t.SLA.EscalateAfter, _ = parseSLADuration(dto.SLA.EscalateAfter)
+		t.SLA.Escalation = dto.SLA.Escalation
+	}
+
 	for _, member := range dto.Members {
 		t.Members = append(t.Members, core.User{
 			ID:       member.ID,
 			Username: member.Username,
 			TeamName: dto.TeamName,
 			IsActive: member.IsActive,
+			Role:     member.Role,
+			Email:    member.Email,
 		})
 	}
 	return &t
 }
 func ToTeamDto(t *core.Team) TeamDto {
-	dto := TeamDto{TeamName: t.Name}
+	dto := TeamDto{
+		TeamName: t.Name,
+		Policy: &ReviewPolicyDto{
+			RequiredRole:  t.Policy.RequiredRole,
+			RequiredCount: t.Policy.RequiredCount,
+			MaxReviewers:  t.Policy.MaxReviewers,
+		},
+	}
+
+	if t.SLA != (core.ReviewSLA{}) {
+		dto.SLA = &ReviewSLADto{Escalation: t.SLA.Escalation}
+		if t.SLA.RemindAfter > 0 {
+			dto.SLA.RemindAfter = t.SLA.RemindAfter.String()
+		}
+		if t.SLA.EscalateAfter > 0 {
+			dto.SLA.EscalateAfter = t.SLA.EscalateAfter.String()
+		}
+	}
 
 	for _, member := range t.Members {
 		dto.Members = append(dto.Members, MemberDto{
 			ID:       member.ID,
 			Username: member.Username,
 			IsActive: member.IsActive,
+			Role:     member.Role,
+			Email:    member.Email,
 		})
 	}
 
 	return dto
 }
 
+func validateTeamDto(dto TeamDto) []FieldError {
+	var details []FieldError
+	invalid := func(field, message string) {
+		details = append(details, FieldError{Field: field, Message: message})
+	}
+
+	if dto.TeamName == "" {
+		invalid("team_name", "is required")
+	}
+	if dto.Policy != nil {
+		if dto.Policy.RequiredRole != "" && !dto.Policy.RequiredRole.IsValid() {
+			invalid("policy.required_role", "is invalid")
+		}
+		if dto.Policy.RequiredCount < 0 {
+			invalid("policy.required_count", "must not be negative")
+		}
+		if dto.Policy.MaxReviewers < 0 {
+			invalid("policy.max_reviewers", "must not be negative")
+		}
+	}
+	if dto.SLA != nil {
+		remindAfter, err := parseSLADuration(dto.SLA.RemindAfter)
+		if err != nil {
+			invalid("sla.remind_after", "is invalid")
+		}
+		escalateAfter, err := parseSLADuration(dto.SLA.EscalateAfter)
+		if err != nil {
+			invalid("sla.escalate_after", "is invalid")
+		}
+		if remindAfter > 0 && escalateAfter > 0 && escalateAfter <= remindAfter {
+			invalid("sla.escalate_after", "must be greater than sla.remind_after")
+		}
+		if dto.SLA.Escalation != "" && !dto.SLA.Escalation.IsValid() {
+			invalid("sla.escalation", "is invalid")
+		}
+	}
+	for i, member := range dto.Members {
+		if member.Role != "" && !member.Role.IsValid() {
+			invalid(fmt.Sprintf("members[%d].role", i), "is invalid")
+		}
+		if member.Email != "" && !core.IsValidEmail(member.Email) {
+			invalid(fmt.Sprintf("members[%d].email", i), "must be a bare address like name@example.com")
+		}
+	}
+	return details
+}
+
 type AddTeamResponse struct {
 	Team TeamDto `json:"team"`
 }
@@ -54,28 +168,29 @@ func NewAddTeamHandler(log *slog.Logger, ts core.TeamService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var team TeamDto
 
-		if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+		if err := decodeJSON(r, &team); err != nil {
 			log.Warn("invalid request body", "error", err)
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "invalid request body")
+			writeDecodeError(w, r, err)
 			return
 		}
 
-		if team.TeamName == "" {
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "team_name is required")
+		if details := validateTeamDto(team); len(details) > 0 {
+			writeValidationError(w, r, details[0].String(), details...)
 			return
 		}
 
-		if err := ts.CreateTeam(r.Context(), ToTeam(team)); err != nil {
+		created := ToTeam(team)
+		if err := ts.CreateTeam(r.Context(), created); err != nil {
 			log.Error("create team", "team", team.TeamName, "error", err)
 
 			status, code, message := toAPIError(err)
-			writeAPIError(w, status, code, message)
+			writeAPIError(w, r, status, code, message)
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
-		if err := json.NewEncoder(w).Encode(AddTeamResponse{Team: team}); err != nil {
+		if err := json.NewEncoder(w).Encode(AddTeamResponse{Team: ToTeamDto(created)}); err != nil {
 			log.Error("encode response", "error", err)
 		}
 	}
@@ -86,7 +201,7 @@ func NewGetTeamHandler(log *slog.Logger, ts core.TeamService) http.HandlerFunc {
 		teamName := r.URL.Query().Get("team_name")
 		if teamName == "" {
 			log.Warn("team_name param is required")
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "team_name param is required")
+			writeFieldError(w, r, "team_name", "is required")
 			return
 		}
 
@@ -95,7 +210,7 @@ func NewGetTeamHandler(log *slog.Logger, ts core.TeamService) http.HandlerFunc {
 			log.Error("get team", "team", teamName, "error", err)
 
 			status, code, message := toAPIError(err)
-			writeAPIError(w, status, code, message)
+			writeAPIError(w, r, status, code, message)
 			return
 		}
 
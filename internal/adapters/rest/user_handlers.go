@@ -18,24 +18,30 @@ type SetUserActiveResponse struct {
 }
 
 type UserDto struct {
-	ID       string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	ID       string        `json:"user_id"`
+	Username string        `json:"username"`
+	TeamName string        `json:"team_name"`
+	IsActive bool          `json:"is_active"`
+	Role     core.UserRole `json:"role"`
+	Email    string        `json:"email,omitempty"`
+	// EmailDigest is false for users who opted out of the email digest.
+	EmailDigest bool `json:"email_digest"`
+	// TenantID is implied by the request and not exposed.
+	TenantID string `json:"-"`
 }
 
 func NewSetUserActiveHandler(log *slog.Logger, us core.UserService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req SetUserActiveRequest
 
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := decodeJSON(r, &req); err != nil {
 			log.Warn("invalid request body", "error", err)
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "invalid request body")
+			writeDecodeError(w, r, err)
 			return
 		}
 
 		if req.UserID == "" {
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "user_id is required")
+			writeFieldError(w, r, "user_id", "is required")
 			return
 		}
 
@@ -44,7 +50,47 @@ func NewSetUserActiveHandler(log *slog.Logger, us core.UserService) http.Handler
 			log.Error("set user active failed", "user", req.UserID, "error", err)
 
 			status, code, message := toAPIError(err)
-			writeAPIError(w, status, code, message)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := SetUserActiveResponse{
+			User: UserDto(*user),
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
+
+type SetEmailDigestRequest struct {
+	UserID  string `json:"user_id"`
+	Enabled bool   `json:"enabled"`
+}
+
+// NewSetEmailDigestHandler opts the user in or out of the email digest.
+func NewSetEmailDigestHandler(log *slog.Logger, us core.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SetEmailDigestRequest
+
+		if err := decodeJSON(r, &req); err != nil {
+			log.Warn("invalid request body", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		if req.UserID == "" {
+			writeFieldError(w, r, "user_id", "is required")
+			return
+		}
+
+		user, err := us.SetEmailDigest(r.Context(), req.UserID, req.Enabled)
+		if err != nil {
+			log.Error("set email digest failed", "user", req.UserID, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
 			return
 		}
 
@@ -84,7 +130,7 @@ func NewGetUserReviewHandler(log *slog.Logger, us core.UserService) http.Handler
 		userID := r.URL.Query().Get("user_id")
 		if userID == "" {
 			log.Warn("user_id parameter is required")
-			writeAPIError(w, http.StatusBadRequest, ErrorCodeNotFound, "user_id parameter is required")
+			writeFieldError(w, r, "user_id", "is required")
 			return
 		}
 
@@ -93,7 +139,7 @@ func NewGetUserReviewHandler(log *slog.Logger, us core.UserService) http.Handler
 			log.Error("get user review requests failed", "user", userID, "error", err)
 
 			status, code, message := toAPIError(err)
-			writeAPIError(w, status, code, message)
+			writeAPIError(w, r, status, code, message)
 			return
 		}
 
@@ -0,0 +1,247 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type AddWebhookRequest struct {
+	TeamName string           `json:"team_name"`
+	URL      string           `json:"url"`
+	Secret   string           `json:"secret,omitempty"`
+	Events   []core.EventType `json:"events,omitempty"`
+}
+
+type WebhookDto struct {
+	ID        int64            `json:"webhook_id"`
+	TeamName  string           `json:"team_name"`
+	URL       string           `json:"url"`
+	Secret    string           `json:"secret,omitempty"`
+	Events    []core.EventType `json:"events"`
+	CreatedAt string           `json:"created_at"`
+}
+
+// ToWebhookDto never exposes the secret, it is returned only on registration.
+func ToWebhookDto(w *core.Webhook) WebhookDto {
+	events := w.Events
+	if len(events) == 0 {
+		events = core.WebhookEvents
+	}
+	return WebhookDto{
+		ID:        w.ID,
+		TeamName:  w.TeamName,
+		URL:       w.URL,
+		Events:    events,
+		CreatedAt: w.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type AddWebhookResponse struct {
+	Webhook WebhookDto `json:"webhook"`
+}
+
+type ListWebhooksResponse struct {
+	TeamName string       `json:"team_name"`
+	Webhooks []WebhookDto `json:"webhooks"`
+}
+
+type RemoveWebhookRequest struct {
+	WebhookID int64 `json:"webhook_id"`
+}
+
+type WebhookDeliveryDto struct {
+	ID         int64          `json:"delivery_id"`
+	EventID    int64          `json:"event_id"`
+	EventType  core.EventType `json:"event_type"`
+	Attempt    int            `json:"attempt"`
+	StatusCode int            `json:"status_code,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	Success    bool           `json:"success"`
+	CreatedAt  string         `json:"created_at"`
+}
+
+type ListDeliveriesResponse struct {
+	WebhookID  int64                `json:"webhook_id"`
+	Deliveries []WebhookDeliveryDto `json:"deliveries"`
+}
+
+func validateAddWebhookRequest(req AddWebhookRequest) []FieldError {
+	var details []FieldError
+	invalid := func(field, message string) {
+		details = append(details, FieldError{Field: field, Message: message})
+	}
+
+	if req.TeamName == "" {
+		invalid("team_name", "is required")
+	}
+	if req.URL == "" {
+		invalid("url", "is required")
+	} else if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		invalid("url", "must be an absolute http(s) URL")
+	}
+	for i, e := range req.Events {
+		if !slices.Contains(core.WebhookEvents, e) {
+			invalid(fmt.Sprintf("events[%d]", i), "is an unsupported event type "+string(e))
+		}
+	}
+	return details
+}
+
+func NewAddWebhookHandler(log *slog.Logger, ws core.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req AddWebhookRequest
+
+		if err := decodeJSON(r, &req); err != nil {
+			log.Warn("invalid request body", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		if details := validateAddWebhookRequest(req); len(details) > 0 {
+			writeValidationError(w, r, details[0].String(), details...)
+			return
+		}
+
+		webhook := &core.Webhook{
+			TeamName: req.TeamName,
+			URL:      req.URL,
+			Secret:   req.Secret,
+			Events:   req.Events,
+		}
+		if err := ws.RegisterWebhook(r.Context(), webhook); err != nil {
+			log.Error("register webhook failed", "team", req.TeamName, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		dto := ToWebhookDto(webhook)
+		dto.Secret = webhook.Secret
+		if err := json.NewEncoder(w).Encode(AddWebhookResponse{Webhook: dto}); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
+
+func NewListWebhooksHandler(log *slog.Logger, ws core.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		teamName := r.URL.Query().Get("team_name")
+		if teamName == "" {
+			log.Warn("team_name param is required")
+			writeFieldError(w, r, "team_name", "is required")
+			return
+		}
+
+		webhooks, err := ws.ListWebhooks(r.Context(), teamName)
+		if err != nil {
+			log.Error("list webhooks failed", "team", teamName, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		resp := ListWebhooksResponse{
+			TeamName: teamName,
+			Webhooks: make([]WebhookDto, len(webhooks)),
+		}
+		for i, webhook := range webhooks {
+			resp.Webhooks[i] = ToWebhookDto(webhook)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
+
+func NewRemoveWebhookHandler(log *slog.Logger, ws core.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RemoveWebhookRequest
+
+		if err := decodeJSON(r, &req); err != nil {
+			log.Warn("invalid request body", "error", err)
+			writeDecodeError(w, r, err)
+			return
+		}
+
+		if req.WebhookID <= 0 {
+			writeFieldError(w, r, "webhook_id", "is required")
+			return
+		}
+
+		if err := ws.DeleteWebhook(r.Context(), req.WebhookID); err != nil {
+			log.Error("remove webhook failed", "webhook_id", req.WebhookID, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewListDeliveriesHandler(log *slog.Logger, ws core.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		webhookID, err := strconv.ParseInt(query.Get("webhook_id"), 10, 64)
+		if err != nil || webhookID <= 0 {
+			log.Warn("webhook_id param is required")
+			writeFieldError(w, r, "webhook_id", "is required")
+			return
+		}
+
+		var limit int
+		if value := query.Get("limit"); value != "" {
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit <= 0 {
+				writeFieldError(w, r, "limit", "must be a positive integer")
+				return
+			}
+		}
+
+		deliveries, err := ws.ListDeliveries(r.Context(), webhookID, limit)
+		if err != nil {
+			log.Error("list webhook deliveries failed", "webhook_id", webhookID, "error", err)
+
+			status, code, message := toAPIError(err)
+			writeAPIError(w, r, status, code, message)
+			return
+		}
+
+		resp := ListDeliveriesResponse{
+			WebhookID:  webhookID,
+			Deliveries: make([]WebhookDeliveryDto, len(deliveries)),
+		}
+		for i, d := range deliveries {
+			resp.Deliveries[i] = WebhookDeliveryDto{
+				ID:         d.ID,
+				EventID:    d.EventID,
+				EventType:  d.EventType,
+				Attempt:    d.Attempt,
+				StatusCode: d.StatusCode,
+				Error:      d.Error,
+				Success:    d.Success,
+				CreatedAt:  d.CreatedAt.Format(time.RFC3339),
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Error("encode response", "error", err)
+		}
+	}
+}
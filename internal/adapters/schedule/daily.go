@@ -0,0 +1,63 @@
+// Package schedule runs periodic background jobs.
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Daily runs job every day at the local time at, given as HH:MM, until ctx is done.
+// Job errors are logged, they do not stop the schedule.
+func Daily(ctx context.Context, log *slog.Logger, at string, job func(ctx context.Context) error) error {
+	clock, err := time.Parse("15:04", at)
+	if err != nil {
+		return fmt.Errorf("parse time %q: %w", at, err)
+	}
+
+	for {
+		next := nextRun(time.Now(), clock)
+		log.Debug("next run scheduled", "at", next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		if err := job(ctx); err != nil {
+			log.Error("scheduled job failed", "error", err)
+		}
+	}
+}
+
+// Every runs job every interval until ctx is done, the first run is after one interval.
+// Job errors are logged, they do not stop the schedule.
+func Every(ctx context.Context, log *slog.Logger, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := job(ctx); err != nil {
+			log.Error("scheduled job failed", "error", err)
+		}
+	}
+}
+
+// nextRun returns the first moment after now with the clock of at.
+func nextRun(now, at time.Time) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
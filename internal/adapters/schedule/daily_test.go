@@ -0,0 +1,19 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRun(t *testing.T) {
+	at := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+	after := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	if got, want := nextRun(before, at), time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("nextRun(before) = %v, want %v", got, want)
+	}
+	if got, want := nextRun(after, at), time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("nextRun(after) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+// Package stream fans stored events out to subscribers within the process.
+package stream
+
+import (
+	"sync"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// Hub delivers published events to subscribers of the recipient users, users are told apart by tenant.
+// A subscriber whose buffer is full is dropped by closing its channel, so publishers never block;
+// a resumed subscriber catches up from the stored events.
+type Hub struct {
+	bufferSize int
+
+	mu          sync.Mutex
+	subscribers map[subscriberKey]map[chan core.Event]struct{}
+	closed      bool
+}
+
+type subscriberKey struct {
+	tenantID string
+	userID   string
+}
+
+var _ core.ReviewUpdates = (*Hub)(nil)
+
+func NewHub(bufferSize int) *Hub {
+	return &Hub{
+		bufferSize:  bufferSize,
+		subscribers: make(map[subscriberKey]map[chan core.Event]struct{}),
+	}
+}
+
+func (h *Hub) Publish(userIDs []string, event core.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, userID := range userIDs {
+		key := subscriberKey{tenantID: event.TenantID, userID: userID}
+		for ch := range h.subscribers[key] {
+			select {
+			case ch <- event:
+			default:
+				h.remove(key, ch)
+			}
+		}
+	}
+}
+
+func (h *Hub) Subscribe(tenantID, userID string) (<-chan core.Event, func()) {
+	key := subscriberKey{tenantID: tenantID, userID: userID}
+	ch := make(chan core.Event, h.bufferSize)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subscribers[key] == nil {
+		h.subscribers[key] = make(map[chan core.Event]struct{})
+	}
+	h.subscribers[key][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(key, ch)
+	}
+}
+
+// Close ends all subscriptions, later ones are closed right away.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for key, chans := range h.subscribers {
+		for ch := range chans {
+			h.remove(key, ch)
+		}
+	}
+}
+
+// remove closes the channel unless it was removed before, h.mu must be held.
+func (h *Hub) remove(key subscriberKey, ch chan core.Event) {
+	chans := h.subscribers[key]
+	if _, ok := chans[ch]; !ok {
+		return
+	}
+	delete(chans, ch)
+	close(ch)
+	if len(chans) == 0 {
+		delete(h.subscribers, key)
+	}
+}
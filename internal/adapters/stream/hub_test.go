@@ -0,0 +1,76 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+func TestHub_DeliversToRecipients(t *testing.T) {
+	hub := NewHub(4)
+	alice, unsubscribeAlice := hub.Subscribe(core.DefaultTenant, "alice")
+	defer unsubscribeAlice()
+	bob, unsubscribeBob := hub.Subscribe(core.DefaultTenant, "bob")
+	defer unsubscribeBob()
+
+	hub.Publish([]string{"alice"}, core.Event{ID: 1, Type: core.EventReviewerAssigned, TenantID: core.DefaultTenant})
+
+	if e := <-alice; e.ID != 1 {
+		t.Errorf("alice got event %d, want 1", e.ID)
+	}
+	select {
+	case e := <-bob:
+		t.Errorf("bob got event %d, want none", e.ID)
+	default:
+	}
+}
+
+func TestHub_IsolatesTenants(t *testing.T) {
+	hub := NewHub(4)
+	acme, unsubscribeAcme := hub.Subscribe("acme", "alice")
+	defer unsubscribeAcme()
+	other, unsubscribeOther := hub.Subscribe("globex", "alice")
+	defer unsubscribeOther()
+
+	hub.Publish([]string{"alice"}, core.Event{ID: 1, TenantID: "acme"})
+
+	if e := <-acme; e.ID != 1 {
+		t.Errorf("alice of acme got event %d, want 1", e.ID)
+	}
+	select {
+	case e := <-other:
+		t.Errorf("alice of globex got event %d, want none", e.ID)
+	default:
+	}
+}
+
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	hub := NewHub(1)
+	events, unsubscribe := hub.Subscribe(core.DefaultTenant, "alice")
+	defer unsubscribe()
+
+	hub.Publish([]string{"alice"}, core.Event{ID: 1, TenantID: core.DefaultTenant})
+	hub.Publish([]string{"alice"}, core.Event{ID: 2, TenantID: core.DefaultTenant})
+
+	if e := <-events; e.ID != 1 {
+		t.Errorf("got event %d, want 1", e.ID)
+	}
+	if _, ok := <-events; ok {
+		t.Error("channel of slow subscriber is open, want closed")
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub(1)
+	events, unsubscribe := hub.Subscribe(core.DefaultTenant, "alice")
+	hub.Close()
+	unsubscribe()
+
+	if _, ok := <-events; ok {
+		t.Error("channel is open after close, want closed")
+	}
+	late, _ := hub.Subscribe(core.DefaultTenant, "bob")
+	if _, ok := <-late; ok {
+		t.Error("subscription after close is open, want closed")
+	}
+}
@@ -0,0 +1,19 @@
+// Package textfmt holds the formatting helpers shared by chat and email templates.
+package textfmt
+
+import (
+	"fmt"
+	"time"
+)
+
+// Age rounds the duration to days, hours or minutes, e.g. "3d", "5h".
+func Age(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	default:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+}
@@ -0,0 +1,23 @@
+package textfmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAge(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "0m"},
+		{45 * time.Minute, "45m"},
+		{5*time.Hour + 59*time.Minute, "5h"},
+		{3*24*time.Hour + 23*time.Hour, "3d"},
+	}
+	for _, tc := range cases {
+		if got := Age(tc.d); got != tc.want {
+			t.Errorf("Age(%s) = %q, want %q", tc.d, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/penkovgd/pr-reviews/internal/adapters/httpx"
+)
+
+// WithTracing starts a server span of every request named by the mux pattern it is routed to,
+// continuing the trace of its traceparent header. It may wrap middlewares around the mux,
+// so the span covers authentication, validation and idempotency as well.
+func WithTracing(mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			// unmatched paths are named by the method alone to keep span names few
+			name := r.Method
+			attrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(r.Method), semconv.URLPath(r.URL.Path)}
+			if _, pattern := mux.Handler(r); pattern != "" {
+				name = pattern
+				_, route, found := strings.Cut(pattern, " ")
+				if !found {
+					route = pattern
+				}
+				attrs = append(attrs, semconv.HTTPRoute(route))
+			}
+
+			ctx, span := tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+			defer span.End()
+
+			recorder := httpx.NewStatusRecorder(w)
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(recorder.Status()))
+			// client errors are the caller's, only server errors fail the span
+			if recorder.Status() >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(recorder.Status()))
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// traced runs fn in a span named after the service method, failing the span with its error.
+func traced[T any](ctx context.Context, name string, fn func(context.Context) (T, error)) (T, error) {
+	ctx, span := tracer().Start(ctx, name)
+	defer span.End()
+	result, err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+// tracedErr is traced for methods returning only an error.
+func tracedErr(ctx context.Context, name string, fn func(context.Context) error) error {
+	_, err := traced(ctx, name, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+type teamService struct {
+	next core.TeamService
+}
+
+// WrapTeamService returns s tracing its methods.
+func WrapTeamService(s core.TeamService) core.TeamService {
+	return &teamService{next: s}
+}
+
+func (s *teamService) CreateTeam(ctx context.Context, team *core.Team) error {
+	return tracedErr(ctx, "TeamService.CreateTeam", func(ctx context.Context) error {
+		return s.next.CreateTeam(ctx, team)
+	})
+}
+
+func (s *teamService) GetTeam(ctx context.Context, teamName string) (*core.Team, error) {
+	return traced(ctx, "TeamService.GetTeam", func(ctx context.Context) (*core.Team, error) {
+		return s.next.GetTeam(ctx, teamName)
+	})
+}
+
+type userService struct {
+	next core.UserService
+}
+
+// WrapUserService returns s tracing its methods.
+func WrapUserService(s core.UserService) core.UserService {
+	return &userService{next: s}
+}
+
+func (s *userService) SetUserActive(ctx context.Context, userID string, isActive bool) (*core.User, error) {
+	return traced(ctx, "UserService.SetUserActive", func(ctx context.Context) (*core.User, error) {
+		return s.next.SetUserActive(ctx, userID, isActive)
+	})
+}
+
+func (s *userService) GetUserReviewRequests(ctx context.Context, userID string) ([]*core.PullRequest, error) {
+	return traced(ctx, "UserService.GetUserReviewRequests", func(ctx context.Context) ([]*core.PullRequest, error) {
+		return s.next.GetUserReviewRequests(ctx, userID)
+	})
+}
+
+func (s *userService) SetEmailDigest(ctx context.Context, userID string, enabled bool) (*core.User, error) {
+	return traced(ctx, "UserService.SetEmailDigest", func(ctx context.Context) (*core.User, error) {
+		return s.next.SetEmailDigest(ctx, userID, enabled)
+	})
+}
+
+func (s *userService) GetReviewEvents(ctx context.Context, userID string, afterID int64) ([]core.Event, error) {
+	return traced(ctx, "UserService.GetReviewEvents", func(ctx context.Context) ([]core.Event, error) {
+		return s.next.GetReviewEvents(ctx, userID, afterID)
+	})
+}
+
+type pullRequestService struct {
+	next core.PullRequestService
+}
+
+// WrapPullRequestService returns s tracing its methods.
+func WrapPullRequestService(s core.PullRequestService) core.PullRequestService {
+	return &pullRequestService{next: s}
+}
+
+func (s *pullRequestService) CreatePR(ctx context.Context, prID, prName, authorID string, prefs core.ReviewerPreferences) (*core.PullRequest, error) {
+	return traced(ctx, "PullRequestService.CreatePR", func(ctx context.Context) (*core.PullRequest, error) {
+		return s.next.CreatePR(ctx, prID, prName, authorID, prefs)
+	})
+}
+
+func (s *pullRequestService) MirrorPR(ctx context.Context, source core.ForgePullRequest, prName, authorID string) (*core.PullRequest, error) {
+	return traced(ctx, "PullRequestService.MirrorPR", func(ctx context.Context) (*core.PullRequest, error) {
+		return s.next.MirrorPR(ctx, source, prName, authorID)
+	})
+}
+
+func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*core.PullRequest, error) {
+	return traced(ctx, "PullRequestService.MergePR", func(ctx context.Context) (*core.PullRequest, error) {
+		return s.next.MergePR(ctx, prID)
+	})
+}
+
+func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) (*core.ReviewReassignment, error) {
+	return traced(ctx, "PullRequestService.ReassignReviewer", func(ctx context.Context) (*core.ReviewReassignment, error) {
+		return s.next.ReassignReviewer(ctx, prID, oldUserID, newUserID)
+	})
+}
+
+func (s *pullRequestService) AddReviewer(ctx context.Context, prID, reviewerID string) (*core.PullRequest, error) {
+	return traced(ctx, "PullRequestService.AddReviewer", func(ctx context.Context) (*core.PullRequest, error) {
+		return s.next.AddReviewer(ctx, prID, reviewerID)
+	})
+}
+
+func (s *pullRequestService) RemoveReviewer(ctx context.Context, prID, reviewerID string) (*core.PullRequest, error) {
+	return traced(ctx, "PullRequestService.RemoveReviewer", func(ctx context.Context) (*core.PullRequest, error) {
+		return s.next.RemoveReviewer(ctx, prID, reviewerID)
+	})
+}
+
+type auditService struct {
+	next core.AuditService
+}
+
+// WrapAuditService returns s tracing its methods.
+func WrapAuditService(s core.AuditService) core.AuditService {
+	return &auditService{next: s}
+}
+
+func (s *auditService) GetPRHistory(ctx context.Context, prID string) ([]core.Event, error) {
+	return traced(ctx, "AuditService.GetPRHistory", func(ctx context.Context) ([]core.Event, error) {
+		return s.next.GetPRHistory(ctx, prID)
+	})
+}
+
+func (s *auditService) ListEvents(ctx context.Context, filter core.EventFilter) ([]core.Event, error) {
+	return traced(ctx, "AuditService.ListEvents", func(ctx context.Context) ([]core.Event, error) {
+		return s.next.ListEvents(ctx, filter)
+	})
+}
+
+type webhookService struct {
+	next core.WebhookService
+}
+
+// WrapWebhookService returns s tracing its methods.
+func WrapWebhookService(s core.WebhookService) core.WebhookService {
+	return &webhookService{next: s}
+}
+
+func (s *webhookService) RegisterWebhook(ctx context.Context, webhook *core.Webhook) error {
+	return tracedErr(ctx, "WebhookService.RegisterWebhook", func(ctx context.Context) error {
+		return s.next.RegisterWebhook(ctx, webhook)
+	})
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context, teamName string) ([]*core.Webhook, error) {
+	return traced(ctx, "WebhookService.ListWebhooks", func(ctx context.Context) ([]*core.Webhook, error) {
+		return s.next.ListWebhooks(ctx, teamName)
+	})
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	return tracedErr(ctx, "WebhookService.DeleteWebhook", func(ctx context.Context) error {
+		return s.next.DeleteWebhook(ctx, webhookID)
+	})
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*core.WebhookDelivery, error) {
+	return traced(ctx, "WebhookService.ListDeliveries", func(ctx context.Context) ([]*core.WebhookDelivery, error) {
+		return s.next.ListDeliveries(ctx, webhookID, limit)
+	})
+}
+
+type staleReviewService struct {
+	next core.StaleReviewService
+}
+
+// WrapStaleReviewService returns s tracing its runs.
+func WrapStaleReviewService(s core.StaleReviewService) core.StaleReviewService {
+	return &staleReviewService{next: s}
+}
+
+func (s *staleReviewService) ProcessStaleReviews(ctx context.Context) (core.StaleReviewReport, error) {
+	return traced(ctx, "StaleReviewService.ProcessStaleReviews", s.next.ProcessStaleReviews)
+}
+
+type chatService struct {
+	next core.ChatService
+}
+
+// WrapChatService returns s tracing its methods.
+func WrapChatService(s core.ChatService) core.ChatService {
+	return &chatService{next: s}
+}
+
+func (s *chatService) SetChatWebhook(ctx context.Context, teamName, url string) error {
+	return tracedErr(ctx, "ChatService.SetChatWebhook", func(ctx context.Context) error {
+		return s.next.SetChatWebhook(ctx, teamName, url)
+	})
+}
+
+type integrationService struct {
+	next core.IntegrationService
+}
+
+// WrapIntegrationService returns s tracing its methods.
+func WrapIntegrationService(s core.IntegrationService) core.IntegrationService {
+	return &integrationService{next: s}
+}
+
+func (s *integrationService) LinkIdentity(ctx context.Context, identity *core.ForgeIdentity) error {
+	return tracedErr(ctx, "IntegrationService.LinkIdentity", func(ctx context.Context) error {
+		return s.next.LinkIdentity(ctx, identity)
+	})
+}
+
+func (s *integrationService) OpenPR(ctx context.Context, source core.ForgePullRequest, title, authorLogin string) (*core.PullRequest, error) {
+	return traced(ctx, "IntegrationService.OpenPR", func(ctx context.Context) (*core.PullRequest, error) {
+		return s.next.OpenPR(ctx, source, title, authorLogin)
+	})
+}
+
+func (s *integrationService) MergePR(ctx context.Context, source core.ForgePullRequest) (*core.PullRequest, error) {
+	return traced(ctx, "IntegrationService.MergePR", func(ctx context.Context) (*core.PullRequest, error) {
+		return s.next.MergePR(ctx, source)
+	})
+}
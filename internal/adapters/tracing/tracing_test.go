@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+// record sets a global provider recording the spans of the test.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return recorder
+}
+
+type fakeTeamService struct {
+	core.TeamService
+	err error
+}
+
+func (s *fakeTeamService) CreateTeam(context.Context, *core.Team) error {
+	return s.err
+}
+
+func TestWithTracing_ContinuesTraceparent(t *testing.T) {
+	recorder := record(t)
+	teams := WrapTeamService(&fakeTeamService{err: core.ErrTeamExists})
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /team/add", func(w http.ResponseWriter, r *http.Request) {
+		if err := teams.CreateTeam(r.Context(), &core.Team{}); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	req := httptest.NewRequest(http.MethodPost, "/team/add", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	WithTracing(mux)(mux).ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	service, server := spans[0], spans[1]
+	if server.Name() != "POST /team/add" {
+		t.Errorf("server span name = %q, want %q", server.Name(), "POST /team/add")
+	}
+	if got := server.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("server span parent = %s, want the span of traceparent", got)
+	}
+	if got := server.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("server span trace = %s, want the trace of traceparent", got)
+	}
+	if !hasAttribute(server.Attributes(), attribute.Int("http.response.status_code", http.StatusBadRequest)) {
+		t.Errorf("server span attributes %v miss the status code", server.Attributes())
+	}
+	if server.Status().Code == codes.Error {
+		t.Error("client error fails the server span")
+	}
+
+	if service.Name() != "TeamService.CreateTeam" {
+		t.Errorf("service span name = %q, want %q", service.Name(), "TeamService.CreateTeam")
+	}
+	if service.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Error("service span is not a child of the server span")
+	}
+	if service.Status().Code != codes.Error {
+		t.Errorf("service span status = %v, want error", service.Status().Code)
+	}
+}
+
+func TestWithTracing_NamesUnmatchedByMethod(t *testing.T) {
+	recorder := record(t)
+	mux := http.NewServeMux()
+
+	WithTracing(mux)(mux).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/1", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != http.MethodGet {
+		t.Fatalf("spans = %v, want one named GET", spans)
+	}
+}
+
+func TestSetup_RejectsUnknownExporter(t *testing.T) {
+	if _, err := Setup(config.TracingConfig{Exporter: "zipkin", SampleRatio: 1}); err == nil {
+		t.Error("unknown exporter is accepted")
+	}
+	if _, err := Setup(config.TracingConfig{Exporter: "stdout", SampleRatio: 2}); err == nil {
+		t.Error("sample ratio above 1 is accepted")
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/penkovgd/closer"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	SignatureHeader = "X-Webhook-Signature-256"
+)
+
+// Payload is the JSON body posted to webhook URLs.
+type Payload struct {
+	ID            int64          `json:"id"`
+	Type          core.EventType `json:"type"`
+	PullRequestID string         `json:"pull_request_id,omitempty"`
+	TeamName      string         `json:"team_name,omitempty"`
+	UserID        string         `json:"user_id,omitempty"`
+	OldUserID     string         `json:"old_user_id,omitempty"`
+	ActorID       string         `json:"actor_id,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+func ToPayload(e core.Event) Payload {
+	return Payload{
+		ID:            e.ID,
+		Type:          e.Type,
+		PullRequestID: e.PRID,
+		TeamName:      e.TeamName,
+		UserID:        e.UserID,
+		OldUserID:     e.OldUserID,
+		ActorID:       e.ActorID,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+// Sign returns the value of SignatureHeader for the body: "sha256=" and hex encoded HMAC-SHA256.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sender posts events to the webhooks of their team, it is an outbox sink.
+type Sender struct {
+	log    *slog.Logger
+	repo   core.WebhookRepository
+	client *http.Client
+	cfg    config.WebhookConfig
+}
+
+func NewSender(log *slog.Logger, repo core.WebhookRepository, cfg config.WebhookConfig) *Sender {
+	return &Sender{
+		log:    log,
+		repo:   repo,
+		client: &http.Client{Timeout: cfg.Timeout},
+		cfg:    cfg,
+	}
+}
+
+// Send posts the event once to every subscribed webhook of its team that has not settled it,
+// i.e. neither accepted nor permanently rejected it. Attempts are recorded as deliveries, retryable
+// failures fail the send so the outbox redelivers the event with backoff until its max attempts.
+func (s *Sender) Send(ctx context.Context, event core.Event) error {
+	if event.TeamName == "" {
+		return nil
+	}
+
+	webhooks, err := s.repo.GetWebhooksByTeam(ctx, event.TeamName)
+	if err != nil {
+		return fmt.Errorf("get webhooks of team %s: %w", event.TeamName, err)
+	}
+
+	deliveries, err := s.repo.GetEventDeliveries(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("get deliveries of event %d: %w", event.ID, err)
+	}
+	attempts := make(map[int64]int)
+	settled := make(map[int64]bool)
+	for _, d := range deliveries {
+		attempts[d.WebhookID] = max(attempts[d.WebhookID], d.Attempt)
+		if d.Success || !retryable(d.StatusCode) {
+			settled[d.WebhookID] = true
+		}
+	}
+
+	body, err := json.Marshal(ToPayload(event))
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(event.Type) || settled[webhook.ID] {
+			continue
+		}
+
+		status, err := s.deliver(ctx, webhook, event, body, attempts[webhook.ID]+1)
+		if err == nil {
+			continue
+		}
+		if !retryable(status) {
+			s.log.Warn("webhook rejected event, given up", "webhook_id", webhook.ID, "event_id", event.ID, "error", err)
+			continue
+		}
+		errs = append(errs, fmt.Errorf("webhook %d: %w", webhook.ID, err))
+	}
+	return errors.Join(errs...)
+}
+
+// deliver posts the event and records the attempt.
+func (s *Sender) deliver(ctx context.Context, webhook *core.Webhook, event core.Event, body []byte, attempt int) (int, error) {
+	status, err := s.post(ctx, webhook, event, body)
+
+	delivery := &core.WebhookDelivery{
+		WebhookID:  webhook.ID,
+		EventID:    event.ID,
+		EventType:  event.Type,
+		Attempt:    attempt,
+		StatusCode: status,
+		Success:    err == nil,
+		CreatedAt:  time.Now(),
+	}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	if err := s.repo.AddDelivery(ctx, delivery); err != nil {
+		s.log.Error("record webhook delivery", "webhook_id", webhook.ID, "error", err)
+	}
+	return status, err
+}
+
+// post sends a single request and returns the response status, 0 if there was no response.
+func (s *Sender) post(ctx context.Context, webhook *core.Webhook, event core.Event, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(event.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send request: %w", err)
+	}
+	defer closer.CloseOrLog(s.log, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryable reports whether a failed attempt with the status may succeed later.
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= 500
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/penkovgd/pr-reviews/internal/config"
+	"github.com/penkovgd/pr-reviews/internal/core"
+)
+
+type fakeRepo struct {
+	core.WebhookRepository
+
+	mu         sync.Mutex
+	webhooks   []*core.Webhook
+	deliveries []*core.WebhookDelivery
+}
+
+func (r *fakeRepo) GetWebhooksByTeam(_ context.Context, teamName string) ([]*core.Webhook, error) {
+	var res []*core.Webhook
+	for _, w := range r.webhooks {
+		if w.TeamName == teamName {
+			res = append(res, w)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeRepo) AddDelivery(_ context.Context, d *core.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, d)
+	return nil
+}
+
+func (r *fakeRepo) GetEventDeliveries(_ context.Context, eventID int64) ([]*core.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var res []*core.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.EventID == eventID {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+func testConfig() config.WebhookConfig {
+	return config.WebhookConfig{Timeout: time.Second}
+}
+
+func TestSender_SendSignedOncePerDispatch(t *testing.T) {
+	const secret = "s3cret"
+	var calls atomic.Int32
+	received := make(chan Payload, 1)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		if got, want := r.Header.Get(SignatureHeader), Sign(secret, body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if got := r.Header.Get(EventHeader); got != string(core.EventReviewerAssigned) {
+			t.Errorf("event header = %q", got)
+		}
+
+		var p Payload
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Errorf("unmarshal payload: %v", err)
+		}
+		received <- p
+	}))
+	defer receiver.Close()
+
+	repo := &fakeRepo{webhooks: []*core.Webhook{
+		{ID: 1, TeamName: "backend", URL: receiver.URL, Secret: secret},
+		{ID: 2, TeamName: "backend", URL: receiver.URL, Secret: secret, Events: []core.EventType{core.EventPRMerged}},
+		{ID: 3, TeamName: "frontend", URL: receiver.URL, Secret: secret},
+	}}
+	sender := NewSender(slog.New(slog.DiscardHandler), repo, testConfig())
+
+	event := core.Event{ID: 42, Type: core.EventReviewerAssigned, PRID: "pr-1", TeamName: "backend", UserID: "u2"}
+	if err := sender.Send(context.Background(), event); err == nil {
+		t.Fatal("retryable failure is not reported to the outbox")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("receiver called %d times, want 1 per dispatch", calls.Load())
+	}
+	if err := sender.Send(context.Background(), event); err != nil {
+		t.Fatalf("redeliver: %v", err)
+	}
+
+	p := <-received
+	if p.ID != 42 || p.PullRequestID != "pr-1" || p.UserID != "u2" {
+		t.Errorf("unexpected payload %+v", p)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("receiver called %d times, want 2", calls.Load())
+	}
+	if len(repo.deliveries) != 2 {
+		t.Fatalf("recorded %d deliveries, want 2", len(repo.deliveries))
+	}
+	if d := repo.deliveries[0]; d.Success || d.StatusCode != http.StatusServiceUnavailable || d.Attempt != 1 {
+		t.Errorf("unexpected first delivery %+v", d)
+	}
+	if d := repo.deliveries[1]; !d.Success || d.Attempt != 2 || d.WebhookID != 1 {
+		t.Errorf("unexpected second delivery %+v", d)
+	}
+}
+
+func TestSender_GivesUpOnClientError(t *testing.T) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer receiver.Close()
+
+	repo := &fakeRepo{webhooks: []*core.Webhook{{ID: 1, TeamName: "backend", URL: receiver.URL}}}
+	sender := NewSender(slog.New(slog.DiscardHandler), repo, testConfig())
+
+	event := core.Event{ID: 1, Type: core.EventPRMerged, TeamName: "backend"}
+	if err := sender.Send(context.Background(), event); err != nil {
+		t.Fatalf("failed delivery is not given up: %v", err)
+	}
+	if err := sender.Send(context.Background(), event); err != nil {
+		t.Fatalf("redeliver: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("receiver called %d times, want 1", calls.Load())
+	}
+	if len(repo.deliveries) != 1 || repo.deliveries[0].Success {
+		t.Errorf("deliveries = %+v, want one failed", repo.deliveries)
+	}
+}
+
+func TestSender_SkipsDeliveredWebhooks(t *testing.T) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer receiver.Close()
+
+	repo := &fakeRepo{
+		webhooks: []*core.Webhook{
+			{ID: 1, TeamName: "backend", URL: receiver.URL},
+			{ID: 2, TeamName: "backend", URL: receiver.URL},
+		},
+		deliveries: []*core.WebhookDelivery{{WebhookID: 1, EventID: 7, Attempt: 1, Success: true}},
+	}
+	sender := NewSender(slog.New(slog.DiscardHandler), repo, testConfig())
+
+	event := core.Event{ID: 7, Type: core.EventPRMerged, TeamName: "backend"}
+	if err := sender.Send(context.Background(), event); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("receiver called %d times, want 1 for the webhook not delivered yet", calls.Load())
+	}
+	if d := repo.deliveries[len(repo.deliveries)-1]; d.WebhookID != 2 || !d.Success {
+		t.Errorf("unexpected delivery %+v", d)
+	}
+}
@@ -10,12 +10,182 @@ import (
 type HTTPConfig struct {
 	Address string        `yaml:"address" env:"API_ADDRESS" env-default:"localhost:8080"`
 	Timeout time.Duration `yaml:"timeout" env:"API_TIMEOUT" env-default:"5s"`
+	// StreamBuffer is the number of events buffered per review stream before a slow client is dropped.
+	StreamBuffer int `yaml:"stream_buffer" env:"API_STREAM_BUFFER" env-default:"64"`
+	// MaxBodySize limits request bodies in bytes, forge webhook payloads included.
+	MaxBodySize int64 `yaml:"max_body_size" env:"API_MAX_BODY_SIZE" env-default:"1048576"`
+	// DrainDelay is how long readiness fails after SIGTERM before the servers stop,
+	// so load balancers stop routing new requests first.
+	DrainDelay time.Duration `yaml:"drain_delay" env:"API_DRAIN_DELAY" env-default:"5s"`
+}
+
+type GRPCConfig struct {
+	Address string `yaml:"address" env:"GRPC_ADDRESS" env-default:"localhost:9090"`
+}
+
+// WebhookConfig configures a single delivery attempt, failed deliveries are retried by the outbox.
+type WebhookConfig struct {
+	Timeout time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT" env-default:"5s"`
+}
+
+type OutboxConfig struct {
+	PollInterval   time.Duration `yaml:"poll_interval" env:"OUTBOX_POLL_INTERVAL" env-default:"1s"`
+	BatchSize      int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+	Lease          time.Duration `yaml:"lease" env:"OUTBOX_LEASE" env-default:"5m"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env:"OUTBOX_INITIAL_BACKOFF" env-default:"10s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env:"OUTBOX_MAX_BACKOFF" env-default:"1h"`
+	// MaxAttempts is the number of deliveries of an event before it is marked failed.
+	MaxAttempts int      `yaml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" env-default:"20"`
+	Sinks       []string `yaml:"sinks" env:"OUTBOX_SINKS" env-default:"webhook,log"`
+	FilePath    string   `yaml:"file_path" env:"OUTBOX_FILE_PATH" env-default:"events.jsonl"`
+}
+
+// AuthConfig enables authentication of REST and gRPC calls by bearer tokens.
+type AuthConfig struct {
+	Enabled bool `yaml:"enabled" env:"AUTH_ENABLED"`
+	// Tokens are static API tokens.
+	Tokens []APITokenConfig `yaml:"tokens"`
+	JWT    JWTConfig        `yaml:"jwt"`
+}
+
+type APITokenConfig struct {
+	Token string `yaml:"token"`
+	// UserID is the user the token acts as, it may be empty for admin and bot tokens.
+	UserID string `yaml:"user_id"`
+	// Role is admin, team-lead, member or bot.
+	Role string `yaml:"role"`
+	// Tenant the token is bound to, admin tokens without it may act in any tenant
+	// and other tokens without it are bound to the default tenant.
+	Tenant string `yaml:"tenant"`
+}
+
+// JWTConfig verifies tokens carrying the user ID in the sub claim, the role in the role claim
+// and optionally the tenant in the tenant claim.
+type JWTConfig struct {
+	// Algorithm is HS256 or RS256, empty disables JWTs.
+	Algorithm string `yaml:"algorithm" env:"AUTH_JWT_ALGORITHM"`
+	// KeyFile holds the HMAC secret or the PEM encoded RSA public key.
+	KeyFile  string `yaml:"key_file" env:"AUTH_JWT_KEY_FILE"`
+	Issuer   string `yaml:"issuer" env:"AUTH_JWT_ISSUER"`
+	Audience string `yaml:"audience" env:"AUTH_JWT_AUDIENCE"`
+}
+
+// RateLimitConfig limits requests of every API token, or client IP for anonymous requests, with token buckets.
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// Default applies to requests outside the groups.
+	Default RateLimitRule `yaml:"default" env-prefix:"RATE_LIMIT_"`
+	// Groups are tried in order, the first one matching a request applies.
+	Groups []RateLimitGroup `yaml:"groups"`
+	// ClientIPHeader names the header the proxy in front of the service puts the client IP in,
+	// the peer address is used if empty.
+	ClientIPHeader string `yaml:"client_ip_header" env:"RATE_LIMIT_CLIENT_IP_HEADER"`
+}
+
+type RateLimitRule struct {
+	RPS   float64 `yaml:"rps" env:"RPS" env-default:"5"`
+	Burst int     `yaml:"burst" env:"BURST" env-default:"10"`
+}
+
+// RateLimitGroup matches requests with one of the methods and path prefixes, empty lists match all.
+type RateLimitGroup struct {
+	Name         string   `yaml:"name"`
+	Methods      []string `yaml:"methods"`
+	PathPrefixes []string `yaml:"path_prefixes"`
+	RPS          float64  `yaml:"rps"`
+	Burst        int      `yaml:"burst"`
+}
+
+// TracingConfig exports OpenTelemetry spans of HTTP requests, service calls and SQL statements.
+type TracingConfig struct {
+	// Exporter is otlp, stdout or empty to disable tracing.
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// Endpoint is the URL of the OTLP/HTTP collector, e.g. http://localhost:4318.
+	// Empty falls back to the standard OTEL_EXPORTER_OTLP_* variables.
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"pr-reviews"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+// IdempotencyConfig controls replays of POST requests repeating an Idempotency-Key header.
+type IdempotencyConfig struct {
+	TTL             time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"1h"`
+}
+
+// StaleReviewConfig holds the default review SLA, teams may override it.
+type StaleReviewConfig struct {
+	Enabled       bool          `yaml:"enabled" env:"STALE_REVIEWS_ENABLED"`
+	Interval      time.Duration `yaml:"interval" env:"STALE_REVIEWS_INTERVAL" env-default:"15m"`
+	RemindAfter   time.Duration `yaml:"remind_after" env:"STALE_REVIEWS_REMIND_AFTER" env-default:"48h"`
+	EscalateAfter time.Duration `yaml:"escalate_after" env:"STALE_REVIEWS_ESCALATE_AFTER" env-default:"120h"`
+	// Escalation is "reassign" or "lead".
+	Escalation string `yaml:"escalation" env:"STALE_REVIEWS_ESCALATION" env-default:"reassign"`
+}
+
+type ChatConfig struct {
+	Timeout time.Duration `yaml:"timeout" env:"CHAT_TIMEOUT" env-default:"5s"`
+	// Webhooks are incoming webhook URLs by team name, URLs set through the API take precedence.
+	Webhooks map[string]string `yaml:"webhooks"`
+	// Templates override the default messages, keys are event types and "digest".
+	Templates map[string]string `yaml:"templates"`
+	// DigestAt is the local time of the daily digest as HH:MM, empty disables digests.
+	DigestAt string `yaml:"digest_at" env:"CHAT_DIGEST_AT"`
+}
+
+type EmailConfig struct {
+	// Host of the SMTP server, empty disables digests.
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     int    `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from" env:"SMTP_FROM"`
+	Subject  string `yaml:"subject" env:"EMAIL_SUBJECT" env-default:"Your open review requests"`
+	// TextTemplate and HTMLTemplate are paths of custom templates, the built-in ones are used if empty.
+	TextTemplate string `yaml:"text_template" env:"EMAIL_TEXT_TEMPLATE"`
+	HTMLTemplate string `yaml:"html_template" env:"EMAIL_HTML_TEMPLATE"`
+	// DigestAt is the local time of the daily digest as HH:MM.
+	DigestAt string `yaml:"digest_at" env:"EMAIL_DIGEST_AT" env-default:"08:00"`
+}
+
+type GitHubConfig struct {
+	WebhookSecret string `yaml:"webhook_secret" env:"GITHUB_WEBHOOK_SECRET"`
+	APIURL        string `yaml:"api_url" env:"GITHUB_API_URL" env-default:"https://api.github.com"`
+	Token         string `yaml:"token" env:"GITHUB_TOKEN"`
+}
+
+type GitLabConfig struct {
+	WebhookToken string `yaml:"webhook_token" env:"GITLAB_WEBHOOK_TOKEN"`
+}
+
+type GiteaConfig struct {
+	WebhookSecret string `yaml:"webhook_secret" env:"GITEA_WEBHOOK_SECRET"`
+	URL           string `yaml:"url" env:"GITEA_URL"`
+	Token         string `yaml:"token" env:"GITEA_TOKEN"`
+}
+
+type IntegrationsConfig struct {
+	Timeout time.Duration `yaml:"timeout" env:"INTEGRATIONS_TIMEOUT" env-default:"10s"`
+	GitHub  GitHubConfig  `yaml:"github"`
+	GitLab  GitLabConfig  `yaml:"gitlab"`
+	Gitea   GiteaConfig   `yaml:"gitea"`
 }
 
 type Config struct {
-	LogLevel   string     `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
-	HTTPConfig HTTPConfig `yaml:"api_server"`
-	DBUrl      string     `yaml:"db_url" env:"DB_URL"`
+	LogLevel      string             `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
+	HTTPConfig    HTTPConfig         `yaml:"api_server"`
+	GRPCConfig    GRPCConfig         `yaml:"grpc_server"`
+	DBUrl         string             `yaml:"db_url" env:"DB_URL"`
+	WebhookConfig WebhookConfig      `yaml:"webhooks"`
+	OutboxConfig  OutboxConfig       `yaml:"outbox"`
+	Integrations  IntegrationsConfig `yaml:"integrations"`
+	Chat          ChatConfig         `yaml:"chat"`
+	Email         EmailConfig        `yaml:"email"`
+	StaleReviews  StaleReviewConfig  `yaml:"stale_reviews"`
+	Idempotency   IdempotencyConfig  `yaml:"idempotency"`
+	Auth          AuthConfig         `yaml:"auth"`
+	RateLimit     RateLimitConfig    `yaml:"rate_limit"`
+	Tracing       TracingConfig      `yaml:"tracing"`
 }
 
 func MustLoad(configPath string) Config {
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"regexp"
+)
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the ID of the user performing the operation.
+func WithActor(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+// ActorFromContext returns the ID of the user performing the operation or empty string if unknown.
+func ActorFromContext(ctx context.Context) string {
+	actorID, _ := ctx.Value(actorKey{}).(string)
+	return actorID
+}
+
+// AccessRole is what the credentials of a caller permit, it is unrelated to UserRole.
+type AccessRole string
+
+const (
+	AccessAdmin    AccessRole = "admin"
+	AccessTeamLead AccessRole = "team-lead"
+	AccessMember   AccessRole = "member"
+	AccessBot      AccessRole = "bot"
+)
+
+func (r AccessRole) IsValid() bool {
+	switch r {
+	case AccessAdmin, AccessTeamLead, AccessMember, AccessBot:
+		return true
+	}
+	return false
+}
+
+// Principal is an authenticated caller. UserID is empty for credentials not bound to a user,
+// TenantID is empty for admins allowed to act in any tenant.
+type Principal struct {
+	UserID   string
+	Role     AccessRole
+	TenantID string
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated caller.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, false for calls made by the service
+// itself or when authentication is disabled.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// DefaultTenant owns the data of single-tenant deployments and everything created before tenants existed.
+const DefaultTenant = "default"
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// IsValidTenantID reports whether id may name a tenant.
+func IsValidTenantID(id string) bool {
+	return tenantIDPattern.MatchString(id)
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx scoped to the tenant, repositories only see data of that tenant.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant the operation is scoped to, DefaultTenant if none was set.
+func TenantFromContext(ctx context.Context) string {
+	if tenantID, _ := ctx.Value(tenantKey{}).(string); tenantID != "" {
+		return tenantID
+	}
+	return DefaultTenant
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	DefaultEventsLimit = 100
+	MaxEventsLimit     = 1000
+)
+
+type auditService struct {
+	eventRepo EventRepository
+	prRepo    PullRequestRepository
+	userRepo  UserRepository
+}
+
+func NewAuditService(eventRepo EventRepository, prRepo PullRequestRepository, userRepo UserRepository) AuditService {
+	return &auditService{
+		eventRepo: eventRepo,
+		prRepo:    prRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// GetPRHistory returns the events of the PR to its author, reviewers and leads of the author's team.
+func (s *auditService) GetPRHistory(ctx context.Context, prID string) ([]Event, error) {
+	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get PR: %w", err)
+	}
+	author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+	participants := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+	if err := authorizeTeamLeadOr(ctx, s.userRepo, author.TeamName, participants...); err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.GetPREvents(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get PR events: %w", err)
+	}
+	return events, nil
+}
+
+// ListEvents returns events matching the filter, team leads may only list events of their team.
+func (s *auditService) ListEvents(ctx context.Context, filter EventFilter) ([]Event, error) {
+	if err := authorize(ctx, AccessAdmin, AccessBot); err != nil {
+		if filter.TeamName == "" {
+			return nil, err
+		}
+		if err := authorizeTeamLead(ctx, s.userRepo, filter.TeamName); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultEventsLimit
+	}
+	filter.Limit = min(filter.Limit, MaxEventsLimit)
+
+	events, err := s.eventRepo.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	return events, nil
+}
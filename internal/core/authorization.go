@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Calls without a principal are made by the service itself or reach it with authentication
+// disabled, the checks below let them through.
+
+// authorize permits callers having one of the roles.
+func authorize(ctx context.Context, roles ...AccessRole) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	for _, role := range roles {
+		if principal.Role == role {
+			return nil
+		}
+	}
+	return ErrForbidden
+}
+
+// authorizeSelf permits the user themselves and privileged callers.
+func authorizeSelf(ctx context.Context, userID string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.UserID == userID {
+		return nil
+	}
+	return authorize(ctx, AccessAdmin, AccessTeamLead, AccessBot)
+}
+
+// authorizeTeamLead permits admins and team leads who are members of the team.
+func authorizeTeamLead(ctx context.Context, userRepo UserRepository, teamName string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.Role == AccessAdmin {
+		return nil
+	}
+	if principal.Role != AccessTeamLead || principal.UserID == "" {
+		return ErrForbidden
+	}
+
+	lead, err := userRepo.GetUserByID(ctx, principal.UserID)
+	if errors.Is(err, ErrUserNotFound) {
+		return ErrForbidden
+	}
+	if err != nil {
+		return fmt.Errorf("get team lead: %w", err)
+	}
+	if lead.TeamName != teamName {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// authorizeTeamLeadOr permits the given users, bots and the callers authorizeTeamLead permits.
+func authorizeTeamLeadOr(ctx context.Context, userRepo UserRepository, teamName string, userIDs ...string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.Role == AccessBot || (principal.UserID != "" && slices.Contains(userIDs, principal.UserID)) {
+		return nil
+	}
+	return authorizeTeamLead(ctx, userRepo, teamName)
+}
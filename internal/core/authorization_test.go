@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeUsers struct {
+	UserRepository
+
+	users map[string]*User
+}
+
+func (r *fakeUsers) GetUserByID(_ context.Context, userID string) (*User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+type fakePRs struct {
+	PullRequestRepository
+
+	prs map[string]*PullRequest
+}
+
+func (r *fakePRs) GetPRByID(_ context.Context, prID string) (*PullRequest, error) {
+	pr, ok := r.prs[prID]
+	if !ok {
+		return nil, ErrPRNotFound
+	}
+	copied := *pr
+	return &copied, nil
+}
+
+func (r *fakePRs) UpdatePR(context.Context, *PullRequest, []Event) error {
+	return nil
+}
+
+type fakeWebhooks struct {
+	WebhookRepository
+
+	webhooks map[int64]*Webhook
+}
+
+func (r *fakeWebhooks) GetWebhook(_ context.Context, webhookID int64) (*Webhook, error) {
+	webhook, ok := r.webhooks[webhookID]
+	if !ok {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+type fakeUpdates struct {
+	ReviewUpdates
+}
+
+func (fakeUpdates) Publish([]string, Event) {}
+
+func newFakeUsers() *fakeUsers {
+	return &fakeUsers{users: map[string]*User{
+		"lead":     {ID: "lead", TeamName: "backend", IsActive: true},
+		"other":    {ID: "other", TeamName: "frontend", IsActive: true},
+		"author":   {ID: "author", TeamName: "backend", IsActive: true},
+		"reviewer": {ID: "reviewer", TeamName: "backend", IsActive: true},
+		"member":   {ID: "member", TeamName: "backend", IsActive: true},
+	}}
+}
+
+func newFakePRs() *fakePRs {
+	return &fakePRs{prs: map[string]*PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "author", Status: StatusOpen, AssignedReviewers: []string{"reviewer"}},
+	}}
+}
+
+func TestAuthorization_ForbidsMembers(t *testing.T) {
+	users := newFakeUsers()
+	prs := newFakePRs()
+	webhooks := &fakeWebhooks{webhooks: map[int64]*Webhook{1: {ID: 1, TeamName: "backend"}}}
+
+	userService := NewUserService(users, prs, nil)
+	prService := NewPullRequestService(prs, users, nil, fakeUpdates{})
+	webhookService := NewWebhookService(webhooks, nil, users)
+	chatService := NewChatService(nil, nil, users)
+	auditService := NewAuditService(nil, prs, users)
+	integrationService := NewIntegrationService(nil, users, prs, prService)
+
+	ctx := WithPrincipal(context.Background(), Principal{UserID: "member", Role: AccessMember})
+	cases := []struct {
+		name string
+		call func() error
+	}{
+		{"SetEmailDigest", func() error {
+			_, err := userService.SetEmailDigest(ctx, "author", true)
+			return err
+		}},
+		{"RegisterWebhook", func() error {
+			return webhookService.RegisterWebhook(ctx, &Webhook{TeamName: "backend", URL: "http://example.com"})
+		}},
+		{"ListWebhooks", func() error {
+			_, err := webhookService.ListWebhooks(ctx, "backend")
+			return err
+		}},
+		{"DeleteWebhook", func() error {
+			return webhookService.DeleteWebhook(ctx, 1)
+		}},
+		{"ListDeliveries", func() error {
+			_, err := webhookService.ListDeliveries(ctx, 1, 0)
+			return err
+		}},
+		{"SetChatWebhook", func() error {
+			return chatService.SetChatWebhook(ctx, "backend", "http://example.com")
+		}},
+		{"LinkIdentity", func() error {
+			return integrationService.LinkIdentity(ctx, &ForgeIdentity{Forge: ForgeGitHub, Login: "octocat", UserID: "author"})
+		}},
+		{"GetPRHistory", func() error {
+			_, err := auditService.GetPRHistory(ctx, "pr-1")
+			return err
+		}},
+		{"ListEvents", func() error {
+			_, err := auditService.ListEvents(ctx, EventFilter{})
+			return err
+		}},
+		{"ListTeamEvents", func() error {
+			_, err := auditService.ListEvents(ctx, EventFilter{TeamName: "backend"})
+			return err
+		}},
+		{"CreatePR", func() error {
+			_, err := prService.CreatePR(ctx, "pr-2", "Add search", "author", ReviewerPreferences{})
+			return err
+		}},
+		{"MergePR", func() error {
+			_, err := prService.MergePR(ctx, "pr-1")
+			return err
+		}},
+		{"ReassignReviewer", func() error {
+			_, err := prService.ReassignReviewer(ctx, "pr-1", "reviewer", "lead")
+			return err
+		}},
+		{"AddReviewer", func() error {
+			_, err := prService.AddReviewer(ctx, "pr-1", "member")
+			return err
+		}},
+		{"RemoveReviewer", func() error {
+			_, err := prService.RemoveReviewer(ctx, "pr-1", "reviewer")
+			return err
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.call(); !errors.Is(err, ErrForbidden) {
+				t.Errorf("err = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeTeamLeadOr(t *testing.T) {
+	users := newFakeUsers()
+
+	cases := []struct {
+		name      string
+		principal Principal
+		wantErr   error
+	}{
+		{"listed user", Principal{UserID: "author", Role: AccessMember}, nil},
+		{"other member", Principal{UserID: "member", Role: AccessMember}, ErrForbidden},
+		{"team lead", Principal{UserID: "lead", Role: AccessTeamLead}, nil},
+		{"lead of another team", Principal{UserID: "other", Role: AccessTeamLead}, ErrForbidden},
+		{"admin", Principal{Role: AccessAdmin}, nil},
+		{"bot", Principal{Role: AccessBot}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := WithPrincipal(context.Background(), tc.principal)
+			err := authorizeTeamLeadOr(ctx, users, "backend", "author")
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("err = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetEmailDigest_ForbidsLeadOfAnotherTeam(t *testing.T) {
+	userService := NewUserService(newFakeUsers(), newFakePRs(), nil)
+
+	ctx := WithPrincipal(context.Background(), Principal{UserID: "other", Role: AccessTeamLead})
+	if _, err := userService.SetEmailDigest(ctx, "author", false); !errors.Is(err, ErrForbidden) {
+		t.Errorf("err = %v, want ErrForbidden", err)
+	}
+}
+
+func TestMergePR_PermitsAuthor(t *testing.T) {
+	prService := NewPullRequestService(newFakePRs(), newFakeUsers(), nil, fakeUpdates{})
+
+	ctx := WithPrincipal(context.Background(), Principal{UserID: "author", Role: AccessMember})
+	pr, err := prService.MergePR(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("MergePR: %v", err)
+	}
+	if pr.Status != StatusMerged {
+		t.Errorf("status = %s, want %s", pr.Status, StatusMerged)
+	}
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+type chatService struct {
+	chatRepo ChatRepository
+	teamRepo TeamRepository
+	userRepo UserRepository
+}
+
+func NewChatService(chatRepo ChatRepository, teamRepo TeamRepository, userRepo UserRepository) ChatService {
+	return &chatService{
+		chatRepo: chatRepo,
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *chatService) SetChatWebhook(ctx context.Context, teamName, url string) error {
+	if err := authorizeTeamLead(ctx, s.userRepo, teamName); err != nil {
+		return err
+	}
+	if _, err := s.teamRepo.GetTeamByName(ctx, teamName); err != nil {
+		return fmt.Errorf("get team: %w", err)
+	}
+
+	if url == "" {
+		if err := s.chatRepo.DeleteChatWebhook(ctx, teamName); err != nil {
+			return fmt.Errorf("delete chat webhook: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.chatRepo.SetChatWebhook(ctx, teamName, url); err != nil {
+		return fmt.Errorf("set chat webhook: %w", err)
+	}
+	return nil
+}
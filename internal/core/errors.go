@@ -18,5 +18,22 @@ var (
 
 	// Reviewer assignment errors
 	ErrReviewerNotAssigned = errors.New("reviewer is not assigned to this PR")
+	ErrReviewerAssigned    = errors.New("reviewer is already assigned to this PR")
 	ErrNoCandidate         = errors.New("no active replacement candidate in team")
+	ErrReviewerNotInTeam   = errors.New("reviewer is not a member of the team")
+	ErrReviewerNotActive   = errors.New("reviewer is not active")
+	ErrReviewerIsAuthor    = errors.New("author cannot review own pull request")
+	ErrReviewerConflict    = errors.New("reviewer is both requested and excluded")
+	ErrTooManyReviewers    = errors.New("too many reviewers requested")
+
+	// Webhook errors
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// Integration errors
+	ErrIdentityNotFound = errors.New("forge login is not mapped to a user")
+	ErrPRSourceNotFound = errors.New("pull request is not mirrored from a forge")
+
+	// Access errors
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrForbidden          = errors.New("operation is not permitted")
 )
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type integrationService struct {
+	identityRepo IdentityRepository
+	userRepo     UserRepository
+	prRepo       PullRequestRepository
+	prService    PullRequestService
+}
+
+func NewIntegrationService(
+	identityRepo IdentityRepository,
+	userRepo UserRepository,
+	prRepo PullRequestRepository,
+	prService PullRequestService,
+) IntegrationService {
+	return &integrationService{
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		prRepo:       prRepo,
+		prService:    prService,
+	}
+}
+
+func (s *integrationService) LinkIdentity(ctx context.Context, identity *ForgeIdentity) error {
+	user, err := s.userRepo.GetUserByID(ctx, identity.UserID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if err := authorizeTeamLead(ctx, s.userRepo, user.TeamName); err != nil {
+		return err
+	}
+
+	if err := s.identityRepo.UpsertIdentity(ctx, identity); err != nil {
+		return fmt.Errorf("upsert identity: %w", err)
+	}
+	return nil
+}
+
+func (s *integrationService) OpenPR(ctx context.Context, source ForgePullRequest, title, authorLogin string) (*PullRequest, error) {
+	prID := source.PRID()
+
+	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil && !errors.Is(err, ErrPRNotFound) {
+		return nil, fmt.Errorf("check PR existence: %w", err)
+	}
+
+	if pr == nil {
+		author, err := s.identityRepo.GetIdentityByLogin(ctx, source.Forge, authorLogin)
+		if err != nil {
+			return nil, fmt.Errorf("get author identity: %w", err)
+		}
+
+		pr, err = s.prService.MirrorPR(ctx, source, title, author.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("mirror PR: %w", err)
+		}
+	}
+	return pr, nil
+}
+
+func (s *integrationService) MergePR(ctx context.Context, source ForgePullRequest) (*PullRequest, error) {
+	pr, err := s.prService.MergePR(ctx, source.PRID())
+	if err != nil {
+		return nil, fmt.Errorf("merge PR: %w", err)
+	}
+	return pr, nil
+}
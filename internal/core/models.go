@@ -1,21 +1,132 @@
 package core
 
 import (
+	"context"
+	"fmt"
+	"net/mail"
+	"slices"
 	"time"
 )
 
+type UserRole string
+
+const (
+	RoleJunior UserRole = "junior"
+	RoleMiddle UserRole = "middle"
+	RoleSenior UserRole = "senior"
+	RoleLead   UserRole = "lead"
+)
+
+// DefaultRole is assigned to users created without an explicit role.
+const DefaultRole = RoleMiddle
+
+// DefaultMaxReviewers limits reviewers of a PR when a team does not set its own limit.
+const DefaultMaxReviewers = 3
+
+var roleSeniority = map[UserRole]int{
+	RoleJunior: 1,
+	RoleMiddle: 2,
+	RoleSenior: 3,
+	RoleLead:   4,
+}
+
+func (r UserRole) IsValid() bool {
+	_, ok := roleSeniority[r]
+	return ok
+}
+
+// AtLeast reports whether r is as senior as other or more.
+func (r UserRole) AtLeast(other UserRole) bool {
+	return roleSeniority[r] >= roleSeniority[other]
+}
+
 type User struct {
-	ID       string `db:"id"`
-	Username string `db:"username"`
-	TeamName string `db:"team_name"`
-	IsActive bool   `db:"is_active"`
+	ID       string   `db:"id"`
+	Username string   `db:"username"`
+	TeamName string   `db:"team_name"`
+	IsActive bool     `db:"is_active"`
+	Role     UserRole `db:"role"`
+	Email    string   `db:"email"`
+	// EmailDigest is false for users who opted out of the email digest.
+	EmailDigest bool   `db:"email_digest"`
+	TenantID    string `db:"tenant_id"`
+}
+
+// IsValidEmail reports whether s is a bare address such as "a@b.c", usable as SMTP recipient.
+// Display names and angle brackets are rejected.
+func IsValidEmail(s string) bool {
+	address, err := mail.ParseAddress(s)
+	return err == nil && address.Address == s
+}
+
+// ReviewPolicy describes mandatory reviewer slots of a team:
+// at least RequiredCount reviewers of every PR must have RequiredRole or higher.
+// MaxReviewers limits how many reviewers a PR may have in total.
+type ReviewPolicy struct {
+	RequiredRole  UserRole `db:"required_role"`
+	RequiredCount int      `db:"required_count"`
+	MaxReviewers  int      `db:"max_reviewers"`
+}
+
+// IsSatisfiedBy reports whether the user fills a mandatory slot.
+func (p ReviewPolicy) IsSatisfiedBy(user *User) bool {
+	return p.RequiredCount > 0 && user.Role.AtLeast(p.RequiredRole)
 }
 
 type Team struct {
 	Name    string `db:"name"`
+	Policy  ReviewPolicy
+	SLA     ReviewSLA
 	Members []User
 }
 
+// EscalationAction is what happens to a review assignment older than ReviewSLA.EscalateAfter.
+type EscalationAction string
+
+const (
+	// EscalateReassign reassigns the review, it falls back to EscalateToLead without candidates.
+	EscalateReassign EscalationAction = "reassign"
+	// EscalateToLead notifies a team lead and keeps the reviewer.
+	EscalateToLead EscalationAction = "lead"
+)
+
+func (a EscalationAction) IsValid() bool {
+	return a == EscalateReassign || a == EscalateToLead
+}
+
+// ReviewSLA tells when reviewers of open PRs are reminded of an assignment
+// and when it is escalated. Zero values of a team SLA fall back to the defaults.
+type ReviewSLA struct {
+	RemindAfter   time.Duration
+	EscalateAfter time.Duration
+	Escalation    EscalationAction
+}
+
+// WithDefaults returns the SLA with zero values taken from defaults.
+func (s ReviewSLA) WithDefaults(defaults ReviewSLA) ReviewSLA {
+	if s.RemindAfter == 0 {
+		s.RemindAfter = defaults.RemindAfter
+	}
+	if s.EscalateAfter == 0 {
+		s.EscalateAfter = defaults.EscalateAfter
+	}
+	if s.Escalation == "" {
+		s.Escalation = defaults.Escalation
+	}
+	return s
+}
+
+// ReviewAssignment is a reviewer assigned to an open PR.
+type ReviewAssignment struct {
+	PRID        string     `db:"pull_request_id"`
+	UserID      string     `db:"user_id"`
+	TeamName    string     `db:"team_name"`
+	AssignedAt  time.Time  `db:"assigned_at"`
+	RemindedAt  *time.Time `db:"reminded_at"`
+	EscalatedAt *time.Time `db:"escalated_at"`
+	TenantID    string     `db:"tenant_id"`
+}
+
 type PullRequestStatus string
 
 const (
@@ -33,7 +144,189 @@ type PullRequest struct {
 	AssignedReviewers []string
 }
 
+// ReviewerPreferences are the author's wishes about reviewers of a new PR.
+// Requested reviewers are assigned before the random fill-up,
+// excluded ones are never picked.
+type ReviewerPreferences struct {
+	Requested []string
+	Excluded  []string
+}
+
 type ReviewReassignment struct {
 	PR            *PullRequest
 	NewReviewerID string
 }
+
+type EventType string
+
+const (
+	EventPRCreated          EventType = "pr.created"
+	EventPRMerged           EventType = "pr.merged"
+	EventReviewerAssigned   EventType = "reviewer.assigned"
+	EventReviewerReassigned EventType = "reviewer.reassigned"
+	EventReviewerRemoved    EventType = "reviewer.removed"
+	EventUserActivated      EventType = "user.activated"
+	EventUserDeactivated    EventType = "user.deactivated"
+	EventTeamCreated        EventType = "team.created"
+	EventTeamMemberAdded    EventType = "team.member_added"
+	EventReviewerReminded   EventType = "reviewer.reminded"
+	EventReviewerEscalated  EventType = "reviewer.escalated"
+)
+
+// Event is an append-only record of an assignment or lifecycle change.
+// UserID is the subject of the event (assigned reviewer, activated user, etc.),
+// OldUserID is set for reassignments only. Escalations have the lead as UserID,
+// empty if the team has none, and the stale reviewer as OldUserID.
+type Event struct {
+	ID        int64     `db:"id"`
+	Type      EventType `db:"type"`
+	PRID      string    `db:"pull_request_id"`
+	TeamName  string    `db:"team_name"`
+	UserID    string    `db:"user_id"`
+	OldUserID string    `db:"old_user_id"`
+	ActorID   string    `db:"actor_id"`
+	CreatedAt time.Time `db:"created_at"`
+	TenantID  string    `db:"tenant_id"`
+}
+
+type EventFilter struct {
+	Type     EventType
+	PRID     string
+	TeamName string
+	UserID   string
+	ActorID  string
+	Since    *time.Time
+	Until    *time.Time
+	Limit    int
+}
+
+// OutboxEntry is an event waiting for delivery to sinks.
+// DeliveredSinks names the sinks that already accepted it.
+type OutboxEntry struct {
+	ID             int64
+	Attempts       int
+	DeliveredSinks []string
+	Event          Event
+}
+
+// IdempotentResponse is the response to a request carrying an idempotency key,
+// Status is 0 while the request is in progress.
+// IdempotencyKey identifies a retried request. Client tells the callers apart,
+// so they never get responses to the requests of each other.
+type IdempotencyKey struct {
+	Client string
+	Key    string
+	Route  string
+}
+
+type IdempotentResponse struct {
+	RequestHash string `db:"request_hash"`
+	Status      int    `db:"status"`
+	ContentType string `db:"content_type"`
+	Body        []byte `db:"body"`
+}
+
+func newEvent(ctx context.Context, eventType EventType) Event {
+	return Event{
+		Type:      eventType,
+		ActorID:   ActorFromContext(ctx),
+		CreatedAt: time.Now(),
+		TenantID:  TenantFromContext(ctx),
+	}
+}
+
+// reviewRecipients returns users whose review requests the event changes,
+// reviewers of a merged PR are given by the caller.
+func reviewRecipients(e Event, reviewers []string) []string {
+	switch e.Type {
+	case EventReviewerAssigned, EventReviewerRemoved:
+		return []string{e.UserID}
+	case EventReviewerReassigned:
+		return []string{e.UserID, e.OldUserID}
+	case EventPRMerged:
+		return reviewers
+	}
+	return nil
+}
+
+// WebhookEvents are the event types a webhook may subscribe to.
+var WebhookEvents = []EventType{
+	EventPRCreated,
+	EventReviewerAssigned,
+	EventReviewerReassigned,
+	EventReviewerRemoved,
+	EventReviewerReminded,
+	EventReviewerEscalated,
+	EventPRMerged,
+}
+
+// Webhook is an outgoing subscription of a team to its PR events.
+// Empty Events means all WebhookEvents.
+type Webhook struct {
+	ID        int64       `db:"id"`
+	TeamName  string      `db:"team_name"`
+	URL       string      `db:"url"`
+	Secret    string      `db:"secret"`
+	Events    []EventType `db:"-"`
+	CreatedAt time.Time   `db:"created_at"`
+}
+
+func (w *Webhook) Subscribed(eventType EventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// WebhookDelivery is a single attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	ID         int64     `db:"id"`
+	WebhookID  int64     `db:"webhook_id"`
+	EventID    int64     `db:"event_id"`
+	EventType  EventType `db:"event_type"`
+	Attempt    int       `db:"attempt"`
+	StatusCode int       `db:"status_code"`
+	Error      string    `db:"error"`
+	Success    bool      `db:"success"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// Forge is a code hosting platform pull requests are mirrored from.
+type Forge string
+
+const (
+	ForgeGitHub Forge = "github"
+	ForgeGitLab Forge = "gitlab"
+	ForgeGitea  Forge = "gitea"
+)
+
+// Forges lists the forges pull requests can be mirrored from.
+var Forges = []Forge{ForgeGitHub, ForgeGitLab, ForgeGitea}
+
+func (f Forge) IsValid() bool {
+	return slices.Contains(Forges, f)
+}
+
+// ForgeIdentity maps a forge login to a user of the service.
+type ForgeIdentity struct {
+	Forge  Forge  `db:"forge"`
+	Login  string `db:"login"`
+	UserID string `db:"user_id"`
+}
+
+// ForgePullRequest identifies a pull request on a forge.
+type ForgePullRequest struct {
+	Forge      Forge  `db:"forge"`
+	Repository string `db:"repository"`
+	Number     int    `db:"number"`
+}
+
+// PRID returns the ID of the mirrored pull request, e.g. "github:owner/repo#42"
+// or "gitlab:group/project!42" following the reference syntax of the forge.
+func (p ForgePullRequest) PRID() string {
+	return fmt.Sprintf("%s:%s%s%d", p.Forge, p.Repository, p.Forge.refSeparator(), p.Number)
+}
+
+func (f Forge) refSeparator() string {
+	if f == ForgeGitLab {
+		return "!"
+	}
+	return "#"
+}
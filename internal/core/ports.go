@@ -2,24 +2,170 @@ package core
 
 import (
 	"context"
+	"time"
 )
 
 type TeamRepository interface {
-	CreateTeam(ctx context.Context, team *Team) error
+	// CreateTeam stores the team, its members and the given events in one transaction.
+	CreateTeam(ctx context.Context, team *Team, events []Event) error
 	GetTeamByName(ctx context.Context, teamName string) (*Team, error)
 }
 
 type UserRepository interface {
-	UpsertUser(ctx context.Context, user *User) error
+	// UpsertUser stores the given events in the same transaction as the user.
+	UpsertUser(ctx context.Context, user *User, events []Event) error
 	GetUserByID(ctx context.Context, userID string) (*User, error)
 	GetUsersByTeam(ctx context.Context, teamName string) ([]*User, error)
+	SetEmailDigest(ctx context.Context, userID string, enabled bool) error
+	// GetDigestRecipients returns active users of all tenants with an email who did not opt out of the digest.
+	GetDigestRecipients(ctx context.Context) ([]*User, error)
 }
 
+// PullRequestRepository stores the given events in the same transaction as the PR change.
 type PullRequestRepository interface {
-	CreatePR(ctx context.Context, pr *PullRequest) error
+	// CreatePR stores source with PRs mirrored from a forge, it is nil for the others.
+	CreatePR(ctx context.Context, pr *PullRequest, source *ForgePullRequest, events []Event) error
 	GetPRByID(ctx context.Context, prID string) (*PullRequest, error)
 	GetPRsByReviewer(ctx context.Context, userID string) ([]*PullRequest, error)
-	UpdatePR(ctx context.Context, pr *PullRequest) error
+	UpdatePR(ctx context.Context, pr *PullRequest, events []Event) error
+	// AddReviewer records assignedBy as the user who assigned the reviewer, nobody if it is empty.
+	// It fails with ErrTooManyReviewers if the PR already has maxReviewers, concurrent additions included.
+	AddReviewer(ctx context.Context, prID, userID, assignedBy string, maxReviewers int, events []Event) error
+	RemoveReviewer(ctx context.Context, prID, userID string, events []Event) error
+	// GetOpenPRsByTeam returns open PRs authored by members of the team, oldest first.
+	GetOpenPRsByTeam(ctx context.Context, teamName string) ([]*PullRequest, error)
+	// GetOpenAssignments returns reviewers of open PRs of all tenants with the team of the author, oldest first.
+	GetOpenAssignments(ctx context.Context) ([]ReviewAssignment, error)
+	// MarkReminded and MarkEscalated set the time of the action on the assignment unless it is set,
+	// the events are stored only then. They return false if it was set or the reviewer is unassigned.
+	MarkReminded(ctx context.Context, prID, userID string, events []Event) (bool, error)
+	MarkEscalated(ctx context.Context, prID, userID string, events []Event) (bool, error)
+}
+
+type EventRepository interface {
+	GetPREvents(ctx context.Context, prID string) ([]Event, error)
+	ListEvents(ctx context.Context, filter EventFilter) ([]Event, error)
+	// GetReviewEvents returns events changing review requests of the user with IDs after afterID, oldest first.
+	GetReviewEvents(ctx context.Context, userID string, afterID int64, limit int) ([]Event, error)
+}
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *Webhook) error
+	GetWebhook(ctx context.Context, webhookID int64) (*Webhook, error)
+	GetWebhooksByTeam(ctx context.Context, teamName string) ([]*Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	AddDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	// GetEventDeliveries returns the delivery attempts of the event to all webhooks.
+	GetEventDeliveries(ctx context.Context, eventID int64) ([]*WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookID int64, limit int) ([]*WebhookDelivery, error)
+}
+
+// ChatRepository stores incoming webhook URLs of team chats.
+type ChatRepository interface {
+	SetChatWebhook(ctx context.Context, teamName, url string) error
+	DeleteChatWebhook(ctx context.Context, teamName string) error
+	// GetChatWebhooks returns URLs by team name.
+	GetChatWebhooks(ctx context.Context) (map[string]string, error)
+	// GetChatWebhookTenants returns tenants having at least one chat webhook.
+	GetChatWebhookTenants(ctx context.Context) ([]string, error)
+}
+
+// IdentityRepository maps forge logins to users, it is shared by all forge integrations.
+type IdentityRepository interface {
+	UpsertIdentity(ctx context.Context, identity *ForgeIdentity) error
+	GetIdentityByLogin(ctx context.Context, forge Forge, login string) (*ForgeIdentity, error)
+	GetIdentityByUser(ctx context.Context, forge Forge, userID string) (*ForgeIdentity, error)
+}
+
+// SourceRepository tells which forge pull request a PR mirrors, sources are stored
+// by PullRequestRepository.CreatePR together with the PR.
+type SourceRepository interface {
+	// GetPRSource fails with ErrPRSourceNotFound for PRs not mirrored from a forge.
+	GetPRSource(ctx context.Context, prID string) (*ForgePullRequest, error)
+}
+
+// ForgeClient manages review requests on the forge a pull request is mirrored from.
+type ForgeClient interface {
+	RequestReviewers(ctx context.Context, pr ForgePullRequest, logins []string) error
+	RemoveReviewers(ctx context.Context, pr ForgePullRequest, logins []string) error
+}
+
+// ReviewUpdates is an in-process feed of stored events that change review requests of users.
+type ReviewUpdates interface {
+	// Publish delivers the event to subscribers of the given users in the tenant of the event.
+	Publish(userIDs []string, event Event)
+	// Subscribe returns events of the user of the tenant. The channel is closed when the subscriber
+	// falls behind or the feed is closed, unsubscribe must be called when done.
+	Subscribe(tenantID, userID string) (events <-chan Event, unsubscribe func())
+}
+
+// OutboxRepository gives access to events committed together with
+// the changes that produced them and not yet delivered to sinks.
+type OutboxRepository interface {
+	// ClaimOutbox leases up to limit due entries of all tenants, they are not claimed again until the lease expires.
+	// Failed entries are never claimed.
+	ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]OutboxEntry, error)
+	CompleteOutbox(ctx context.Context, entryID int64) error
+	// RetryOutbox reschedules the entry, remembering the sinks that accepted it so far.
+	RetryOutbox(ctx context.Context, entryID int64, delivered []string, delay time.Duration, lastErr string) error
+	// FailOutbox keeps the entry for inspection and stops its delivery.
+	FailOutbox(ctx context.Context, entryID int64, delivered []string, lastErr string) error
+}
+
+// EventSink receives events drained from the outbox.
+// Delivery is at-least-once, so sinks must tolerate duplicates.
+type EventSink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// IdempotencyRepository stores responses to requests that may be retried with the same
+// idempotency key. Keys are scoped to a client and route and expire after their TTL.
+type IdempotencyRepository interface {
+	// ReserveIdempotencyKey claims the key for the request. If the key is already claimed,
+	// it returns false with the response stored for the claiming request.
+	ReserveIdempotencyKey(ctx context.Context, key IdempotencyKey, requestHash string, ttl time.Duration) (*IdempotentResponse, bool, error)
+	SaveIdempotentResponse(ctx context.Context, key IdempotencyKey, response *IdempotentResponse) error
+	// ReleaseIdempotencyKey drops the claim, the next request with the key is processed anew.
+	ReleaseIdempotencyKey(ctx context.Context, key IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// MigrationStatus is the schema version of the database and the latest one the service ships.
+// Dirty means a migration failed midway and the schema needs a manual fix.
+type MigrationStatus struct {
+	Version uint
+	Latest  uint
+	Dirty   bool
+}
+
+// Migrated tells whether every migration of the service is applied cleanly.
+func (s MigrationStatus) Migrated() bool {
+	return !s.Dirty && s.Version >= s.Latest
+}
+
+// HealthChecker reports whether the database can serve requests.
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+	MigrationStatus(ctx context.Context) (MigrationStatus, error)
+}
+
+// Authenticator resolves credentials presented by callers,
+// it fails with ErrInvalidCredentials for unknown or expired ones.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (Principal, error)
+}
+
+// RateLimit allows Rate calls per second on average in bursts of up to Burst calls.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter keeps a token bucket per key.
+type RateLimiter interface {
+	// Allow takes a token from the bucket of the key. When the bucket is empty,
+	// it returns false and the time until the next token.
+	Allow(ctx context.Context, key string, limit RateLimit) (bool, time.Duration, error)
 }
 
 type TeamService interface {
@@ -30,12 +176,61 @@ type TeamService interface {
 type UserService interface {
 	SetUserActive(ctx context.Context, userID string, isActive bool) (*User, error)
 	GetUserReviewRequests(ctx context.Context, userID string) ([]*PullRequest, error)
+	SetEmailDigest(ctx context.Context, userID string, enabled bool) (*User, error)
+	// GetReviewEvents returns events changing review requests of the user after the event afterID,
+	// nothing for afterID 0.
+	GetReviewEvents(ctx context.Context, userID string, afterID int64) ([]Event, error)
 }
 
 type PullRequestService interface {
-	CreatePR(ctx context.Context, prID, prName, authorID string) (*PullRequest, error)
+	CreatePR(ctx context.Context, prID, prName, authorID string, prefs ReviewerPreferences) (*PullRequest, error)
+	// MirrorPR creates the PR mirroring the forge pull request like CreatePR, its source is stored
+	// in the same transaction, so sinks of its events always find it.
+	MirrorPR(ctx context.Context, source ForgePullRequest, prName, authorID string) (*PullRequest, error)
+	// MergePR and the reviewer changes are permitted to the author, leads of the author's team,
+	// admins and bots, a reviewer may also hand over or drop their own review.
 	MergePR(ctx context.Context, prID string) (*PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*ReviewReassignment, error)
+	ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) (*ReviewReassignment, error)
+	// AddReviewer and RemoveReviewer attribute the change to the actor of ctx.
+	AddReviewer(ctx context.Context, prID, reviewerID string) (*PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID, reviewerID string) (*PullRequest, error)
+}
+
+type AuditService interface {
+	GetPRHistory(ctx context.Context, prID string) ([]Event, error)
+	ListEvents(ctx context.Context, filter EventFilter) ([]Event, error)
+}
+
+type WebhookService interface {
+	RegisterWebhook(ctx context.Context, webhook *Webhook) error
+	ListWebhooks(ctx context.Context, teamName string) ([]*Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*WebhookDelivery, error)
+}
+
+// StaleReviewReport counts actions taken on stale review assignments.
+type StaleReviewReport struct {
+	Reminded   int
+	Reassigned int
+	Escalated  int
+}
+
+type StaleReviewService interface {
+	// ProcessStaleReviews reminds of and escalates assignments older than the SLA of their team.
+	ProcessStaleReviews(ctx context.Context) (StaleReviewReport, error)
+}
+
+type ChatService interface {
+	// SetChatWebhook sets the incoming webhook URL of the team chat, an empty URL removes it.
+	SetChatWebhook(ctx context.Context, teamName, url string) error
+}
+
+// IntegrationService mirrors forge pull requests into the service.
+type IntegrationService interface {
+	LinkIdentity(ctx context.Context, identity *ForgeIdentity) error
+	// OpenPR creates the mirrored PR, it is a no-op for already mirrored ones.
+	OpenPR(ctx context.Context, source ForgePullRequest, title, authorLogin string) (*PullRequest, error)
+	MergePR(ctx context.Context, source ForgePullRequest) (*PullRequest, error)
 }
 
 type Statistics interface {
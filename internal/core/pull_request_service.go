@@ -5,22 +5,46 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"time"
 )
 
+const reviewersCount = 2
+
 type pullRequestService struct {
 	prRepo   PullRequestRepository
 	userRepo UserRepository
+	teamRepo TeamRepository
+	updates  ReviewUpdates
 }
 
-func NewPullRequestService(prRepo PullRequestRepository, userRepo UserRepository) PullRequestService {
+func NewPullRequestService(prRepo PullRequestRepository, userRepo UserRepository, teamRepo TeamRepository, updates ReviewUpdates) PullRequestService {
 	return &pullRequestService{
 		prRepo:   prRepo,
 		userRepo: userRepo,
+		teamRepo: teamRepo,
+		updates:  updates,
+	}
+}
+
+// publishReviewUpdates feeds stored events to the users whose review requests they change.
+func (s *pullRequestService) publishReviewUpdates(events []Event, reviewers []string) {
+	for _, e := range events {
+		if userIDs := reviewRecipients(e, reviewers); len(userIDs) > 0 {
+			s.updates.Publish(userIDs, e)
+		}
 	}
 }
 
-func (s *pullRequestService) CreatePR(ctx context.Context, prID, prName, authorID string) (*PullRequest, error) {
+func (s *pullRequestService) CreatePR(ctx context.Context, prID, prName, authorID string, prefs ReviewerPreferences) (*PullRequest, error) {
+	return s.createPR(ctx, prID, prName, authorID, prefs, nil)
+}
+
+func (s *pullRequestService) MirrorPR(ctx context.Context, source ForgePullRequest, prName, authorID string) (*PullRequest, error) {
+	return s.createPR(ctx, source.PRID(), prName, authorID, ReviewerPreferences{}, &source)
+}
+
+func (s *pullRequestService) createPR(ctx context.Context, prID, prName, authorID string, prefs ReviewerPreferences, source *ForgePullRequest) (*PullRequest, error) {
 	existingPR, err := s.prRepo.GetPRByID(ctx, prID)
 	if err == nil && existingPR != nil {
 		return nil, ErrPRExists
@@ -33,11 +57,14 @@ func (s *pullRequestService) CreatePR(ctx context.Context, prID, prName, authorI
 	if err != nil {
 		return nil, fmt.Errorf("get author: %w", err)
 	}
+	if err := authorizeTeamLeadOr(ctx, s.userRepo, author.TeamName, authorID); err != nil {
+		return nil, err
+	}
 	if !author.IsActive {
 		return nil, ErrUserNotActive
 	}
 
-	reviewerIDs, err := s.assignReviewers(ctx, authorID)
+	reviewerIDs, err := s.assignReviewers(ctx, author, prefs)
 	if err != nil {
 		return nil, fmt.Errorf("assign reviewers: %w", err)
 	}
@@ -52,50 +79,131 @@ func (s *pullRequestService) CreatePR(ctx context.Context, prID, prName, authorI
 		CreatedAt:         &now,
 	}
 
-	if err := s.prRepo.CreatePR(ctx, pr); err != nil {
+	created := newEvent(ctx, EventPRCreated)
+	created.PRID = prID
+	created.TeamName = author.TeamName
+	created.UserID = authorID
+	if created.ActorID == "" {
+		created.ActorID = authorID
+	}
+	events := []Event{created}
+	for _, reviewerID := range reviewerIDs {
+		assigned := created
+		assigned.Type = EventReviewerAssigned
+		assigned.UserID = reviewerID
+		events = append(events, assigned)
+	}
+
+	if err := s.prRepo.CreatePR(ctx, pr, source, events); err != nil {
 		return nil, fmt.Errorf("create PR: %w", err)
 	}
+	s.publishReviewUpdates(events, pr.AssignedReviewers)
 
 	return pr, nil
 }
 
-func (s *pullRequestService) assignReviewers(ctx context.Context, authorID string) ([]string, error) {
-	author, err := s.userRepo.GetUserByID(ctx, authorID)
+func (s *pullRequestService) assignReviewers(ctx context.Context, author *User, prefs ReviewerPreferences) ([]string, error) {
+	team, err := s.teamRepo.GetTeamByName(ctx, author.TeamName)
 	if err != nil {
-		return nil, fmt.Errorf("get author: %w", err)
+		return nil, fmt.Errorf("get author team: %w", err)
 	}
 
-	teamUsers, err := s.userRepo.GetUsersByTeam(ctx, author.TeamName)
-	if err != nil {
-		return nil, fmt.Errorf("get team users: %w", err)
+	members := make(map[string]*User, len(team.Members))
+	for i := range team.Members {
+		members[team.Members[i].ID] = &team.Members[i]
 	}
 
-	var candidates []string
-	for _, user := range teamUsers {
-		if user.IsActive && user.ID != authorID {
-			candidates = append(candidates, user.ID)
+	excluded := make(map[string]bool, len(prefs.Excluded))
+	for _, userID := range prefs.Excluded {
+		if _, ok := members[userID]; !ok {
+			return nil, fmt.Errorf("excluded reviewer %s: %w", userID, ErrReviewerNotInTeam)
 		}
+		excluded[userID] = true
 	}
 
-	return s.selectRandomReviewers(candidates, 2), nil
-}
-
-func (s *pullRequestService) selectRandomReviewers(candidates []string, count int) []string {
-	if len(candidates) == 0 {
-		return nil
+	var requested []*User
+	requestedSet := make(map[string]bool, len(prefs.Requested))
+	for _, userID := range prefs.Requested {
+		if requestedSet[userID] {
+			continue
+		}
+		user, ok := members[userID]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("requested reviewer %s: %w", userID, ErrReviewerNotInTeam)
+		case userID == author.ID:
+			return nil, fmt.Errorf("requested reviewer %s: %w", userID, ErrReviewerIsAuthor)
+		case excluded[userID]:
+			return nil, fmt.Errorf("requested reviewer %s: %w", userID, ErrReviewerConflict)
+		case !user.IsActive:
+			return nil, fmt.Errorf("requested reviewer %s: %w", userID, ErrReviewerNotActive)
+		}
+		requested = append(requested, user)
+		requestedSet[userID] = true
+	}
+	if len(requested) > team.Policy.MaxReviewers {
+		return nil, ErrTooManyReviewers
 	}
 
-	if count > len(candidates) {
-		count = len(candidates)
+	var candidates []*User
+	for i := range team.Members {
+		user := &team.Members[i]
+		if user.IsActive && user.ID != author.ID && !excluded[user.ID] && !requestedSet[user.ID] {
+			candidates = append(candidates, user)
+		}
 	}
 
-	shuffled := make([]string, len(candidates))
+	count := max(len(requested), min(reviewersCount, team.Policy.MaxReviewers))
+	return s.selectRandomReviewers(requested, candidates, count, team.Policy), nil
+}
+
+// selectRandomReviewers keeps requested reviewers, fills the mandatory policy
+// slots they do not cover and then picks the rest randomly among candidates.
+func (s *pullRequestService) selectRandomReviewers(requested, candidates []*User, count int, policy ReviewPolicy) []string {
+	shuffled := make([]*User, len(candidates))
 	copy(shuffled, candidates)
 	rand.Shuffle(len(shuffled), func(i, j int) {
 		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
 	})
 
-	return shuffled[:count]
+	selected := make([]string, 0, count)
+	taken := make(map[string]bool, count)
+
+	covered := 0
+	for _, user := range requested {
+		selected = append(selected, user.ID)
+		taken[user.ID] = true
+		if policy.IsSatisfiedBy(user) {
+			covered++
+		}
+	}
+
+	mandatory := min(policy.RequiredCount, count)
+	for _, user := range shuffled {
+		if covered >= mandatory || len(selected) == count {
+			break
+		}
+		if policy.IsSatisfiedBy(user) {
+			selected = append(selected, user.ID)
+			taken[user.ID] = true
+			covered++
+		}
+	}
+
+	for _, user := range shuffled {
+		if len(selected) == count {
+			break
+		}
+		if !taken[user.ID] {
+			selected = append(selected, user.ID)
+			taken[user.ID] = true
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
 }
 
 func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*PullRequest, error) {
@@ -104,6 +212,14 @@ func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*PullReq
 		return nil, fmt.Errorf("get PR: %w", err)
 	}
 
+	teamName, err := s.authorTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeTeamLeadOr(ctx, s.userRepo, teamName, pr.AuthorID); err != nil {
+		return nil, err
+	}
+
 	if pr.Status == StatusMerged {
 		return pr, nil
 	}
@@ -112,19 +228,36 @@ func (s *pullRequestService) MergePR(ctx context.Context, prID string) (*PullReq
 	now := time.Now()
 	pr.MergedAt = &now
 
-	if err := s.prRepo.UpdatePR(ctx, pr); err != nil {
+	merged := newEvent(ctx, EventPRMerged)
+	merged.PRID = pr.ID
+	merged.TeamName = teamName
+	merged.CreatedAt = now
+
+	events := []Event{merged}
+	if err := s.prRepo.UpdatePR(ctx, pr, events); err != nil {
 		return nil, fmt.Errorf("update PR: %w", err)
 	}
+	s.publishReviewUpdates(events, pr.AssignedReviewers)
 
 	return pr, nil
 }
 
-func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*ReviewReassignment, error) {
+// ReassignReviewer replaces oldUserID with newUserID or,
+// when newUserID is empty, with a random candidate.
+func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) (*ReviewReassignment, error) {
 	pr, err := s.prRepo.GetPRByID(ctx, prID)
 	if err != nil {
 		return nil, fmt.Errorf("get PR: %w", err)
 	}
 
+	teamName, err := s.authorTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeTeamLeadOr(ctx, s.userRepo, teamName, pr.AuthorID, oldUserID); err != nil {
+		return nil, err
+	}
+
 	if pr.Status == StatusMerged {
 		return nil, ErrPRMerged
 	}
@@ -144,9 +277,16 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUser
 	copy(excludeUsers, pr.AssignedReviewers)
 	excludeUsers = append(excludeUsers, pr.AuthorID)
 
-	newReviewerID, err := s.findReplacement(ctx, oldUserID, excludeUsers)
-	if err != nil {
-		return nil, fmt.Errorf("find replacement: %w", err)
+	newReviewerID := newUserID
+	if newReviewerID != "" {
+		if err := s.checkReplacement(ctx, pr, oldUserID, newReviewerID); err != nil {
+			return nil, fmt.Errorf("check replacement: %w", err)
+		}
+	} else {
+		newReviewerID, err = s.findReplacement(ctx, oldUserID, pr.AssignedReviewers, excludeUsers)
+		if err != nil {
+			return nil, fmt.Errorf("find replacement: %w", err)
+		}
 	}
 
 	newReviewers := make([]string, 0, len(pr.AssignedReviewers))
@@ -160,9 +300,17 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUser
 
 	pr.AssignedReviewers = newReviewers
 
-	if err := s.prRepo.UpdatePR(ctx, pr); err != nil {
+	reassigned := newEvent(ctx, EventReviewerReassigned)
+	reassigned.PRID = pr.ID
+	reassigned.TeamName = teamName
+	reassigned.UserID = newReviewerID
+	reassigned.OldUserID = oldUserID
+
+	events := []Event{reassigned}
+	if err := s.prRepo.UpdatePR(ctx, pr, events); err != nil {
 		return nil, fmt.Errorf("update PR: %w", err)
 	}
+	s.publishReviewUpdates(events, pr.AssignedReviewers)
 
 	return &ReviewReassignment{
 		PR:            pr,
@@ -170,15 +318,177 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldUser
 	}, nil
 }
 
-func (s *pullRequestService) findReplacement(ctx context.Context, oldReviewerID string, excludeUsers []string) (string, error) {
+func (s *pullRequestService) AddReviewer(ctx context.Context, prID, reviewerID string) (*PullRequest, error) {
+	pr, err := s.getOpenPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := s.authorTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeTeamLeadOr(ctx, s.userRepo, teamName, pr.AuthorID); err != nil {
+		return nil, err
+	}
+
+	actorID := ActorFromContext(ctx)
+	if err := s.checkActor(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	if pr.AuthorID == reviewerID {
+		return nil, ErrReviewerIsAuthor
+	}
+	if slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil, ErrReviewerAssigned
+	}
+
+	team, err := s.teamRepo.GetTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get author team: %w", err)
+	}
+
+	idx := slices.IndexFunc(team.Members, func(u User) bool { return u.ID == reviewerID })
+	if idx < 0 {
+		return nil, fmt.Errorf("reviewer %s: %w", reviewerID, ErrReviewerNotInTeam)
+	}
+	if !team.Members[idx].IsActive {
+		return nil, fmt.Errorf("reviewer %s: %w", reviewerID, ErrReviewerNotActive)
+	}
+	if len(pr.AssignedReviewers) >= team.Policy.MaxReviewers {
+		return nil, ErrTooManyReviewers
+	}
+
+	assigned := newEvent(ctx, EventReviewerAssigned)
+	assigned.PRID = pr.ID
+	assigned.TeamName = team.Name
+	assigned.UserID = reviewerID
+
+	events := []Event{assigned}
+	if err := s.prRepo.AddReviewer(ctx, pr.ID, reviewerID, actorID, team.Policy.MaxReviewers, events); err != nil {
+		return nil, fmt.Errorf("add reviewer: %w", err)
+	}
+	s.publishReviewUpdates(events, pr.AssignedReviewers)
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+	return pr, nil
+}
+
+func (s *pullRequestService) RemoveReviewer(ctx context.Context, prID, reviewerID string) (*PullRequest, error) {
+	pr, err := s.getOpenPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := s.authorTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeTeamLeadOr(ctx, s.userRepo, teamName, pr.AuthorID, reviewerID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkActor(ctx, ActorFromContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil, ErrReviewerNotAssigned
+	}
+
+	removed := newEvent(ctx, EventReviewerRemoved)
+	removed.PRID = pr.ID
+	removed.TeamName = teamName
+	removed.UserID = reviewerID
+
+	events := []Event{removed}
+	if err := s.prRepo.RemoveReviewer(ctx, pr.ID, reviewerID, events); err != nil {
+		return nil, fmt.Errorf("remove reviewer: %w", err)
+	}
+	s.publishReviewUpdates(events, pr.AssignedReviewers)
+
+	pr.AssignedReviewers = slices.DeleteFunc(pr.AssignedReviewers, func(id string) bool { return id == reviewerID })
+	return pr, nil
+}
+
+func (s *pullRequestService) getOpenPR(ctx context.Context, prID string) (*PullRequest, error) {
+	pr, err := s.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get PR: %w", err)
+	}
+
+	if pr.Status == StatusMerged {
+		return nil, ErrPRMerged
+	}
+	return pr, nil
+}
+
+// authorTeam returns the team of the PR author, events of the PR belong to it.
+func (s *pullRequestService) authorTeam(ctx context.Context, pr *PullRequest) (string, error) {
+	author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return "", fmt.Errorf("get author: %w", err)
+	}
+	return author.TeamName, nil
+}
+
+// checkActor makes sure the user changing reviewers exists and is active,
+// the change is not attributed to anyone when the actor is unknown.
+func (s *pullRequestService) checkActor(ctx context.Context, actorID string) error {
+	if actorID == "" {
+		return nil
+	}
+	actor, err := s.userRepo.GetUserByID(ctx, actorID)
+	if err != nil {
+		return fmt.Errorf("get actor: %w", err)
+	}
+	if !actor.IsActive {
+		return ErrUserNotActive
+	}
+	return nil
+}
+
+// checkReplacement validates an explicitly chosen replacement
+// against the same rules findReplacement applies to candidates.
+func (s *pullRequestService) checkReplacement(ctx context.Context, pr *PullRequest, oldReviewerID, newReviewerID string) error {
+	if newReviewerID == pr.AuthorID {
+		return ErrReviewerIsAuthor
+	}
+	if slices.Contains(pr.AssignedReviewers, newReviewerID) {
+		return ErrReviewerAssigned
+	}
+
+	oldReviewer, err := s.userRepo.GetUserByID(ctx, oldReviewerID)
+	if err != nil {
+		return fmt.Errorf("get old reviewer: %w", err)
+	}
+	newReviewer, err := s.userRepo.GetUserByID(ctx, newReviewerID)
+	if err != nil {
+		return fmt.Errorf("get new reviewer: %w", err)
+	}
+
+	if newReviewer.TeamName != oldReviewer.TeamName {
+		return fmt.Errorf("reviewer %s: %w", newReviewerID, ErrReviewerNotInTeam)
+	}
+	if !newReviewer.IsActive {
+		return fmt.Errorf("reviewer %s: %w", newReviewerID, ErrReviewerNotActive)
+	}
+	return nil
+}
+
+// findReplacement picks a random active member of the old reviewer's team.
+// If the old reviewer filled a mandatory policy slot that the remaining
+// reviewers do not cover, candidates satisfying the policy are preferred.
+func (s *pullRequestService) findReplacement(ctx context.Context, oldReviewerID string, reviewers, excludeUsers []string) (string, error) {
 	oldReviewer, err := s.userRepo.GetUserByID(ctx, oldReviewerID)
 	if err != nil {
 		return "", fmt.Errorf("get old reviewer: %w", err)
 	}
 
-	teamUsers, err := s.userRepo.GetUsersByTeam(ctx, oldReviewer.TeamName)
+	team, err := s.teamRepo.GetTeamByName(ctx, oldReviewer.TeamName)
 	if err != nil {
-		return "", fmt.Errorf("get team users: %w", err)
+		return "", fmt.Errorf("get old reviewer team: %w", err)
 	}
 
 	excludeSet := make(map[string]bool)
@@ -186,10 +496,11 @@ func (s *pullRequestService) findReplacement(ctx context.Context, oldReviewerID
 		excludeSet[userID] = true
 	}
 
-	var candidates []string
-	for _, user := range teamUsers {
+	var candidates []*User
+	for i := range team.Members {
+		user := &team.Members[i]
 		if user.IsActive && !excludeSet[user.ID] {
-			candidates = append(candidates, user.ID)
+			candidates = append(candidates, user)
 		}
 	}
 
@@ -197,5 +508,32 @@ func (s *pullRequestService) findReplacement(ctx context.Context, oldReviewerID
 		return "", ErrNoCandidate
 	}
 
-	return candidates[rand.Intn(len(candidates))], nil
+	if policy := team.Policy; policy.IsSatisfiedBy(oldReviewer) {
+		members := make(map[string]*User, len(team.Members))
+		for i := range team.Members {
+			members[team.Members[i].ID] = &team.Members[i]
+		}
+
+		covered := 0
+		for _, reviewerID := range reviewers {
+			if reviewer, ok := members[reviewerID]; ok && reviewerID != oldReviewerID && policy.IsSatisfiedBy(reviewer) {
+				covered++
+			}
+		}
+
+		if covered < policy.RequiredCount {
+			var qualified []*User
+			for _, user := range candidates {
+				if policy.IsSatisfiedBy(user) {
+					qualified = append(qualified, user)
+				}
+			}
+			// keep the invariant when possible, otherwise fall back to any candidate
+			if len(qualified) > 0 {
+				candidates = qualified
+			}
+		}
+	}
+
+	return candidates[rand.Intn(len(candidates))].ID, nil
 }
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type staleReviewService struct {
+	prRepo    PullRequestRepository
+	teamRepo  TeamRepository
+	prService PullRequestService
+	defaults  ReviewSLA
+}
+
+func NewStaleReviewService(prRepo PullRequestRepository, teamRepo TeamRepository, prService PullRequestService, defaults ReviewSLA) StaleReviewService {
+	return &staleReviewService{
+		prRepo:    prRepo,
+		teamRepo:  teamRepo,
+		prService: prService,
+		defaults:  defaults,
+	}
+}
+
+// ProcessStaleReviews escalates an assignment once and reminds of it once,
+// a reminder is skipped if the assignment is already due for escalation.
+// Assignments of all tenants are processed, each in the scope of its tenant.
+// Failures of single assignments do not stop processing of the others.
+func (s *staleReviewService) ProcessStaleReviews(ctx context.Context) (StaleReviewReport, error) {
+	var report StaleReviewReport
+
+	assignments, err := s.prRepo.GetOpenAssignments(ctx)
+	if err != nil {
+		return report, fmt.Errorf("get open assignments: %w", err)
+	}
+
+	now := time.Now()
+	type teamKey struct{ tenantID, name string }
+	teams := make(map[teamKey]*Team)
+	var errs []error
+	for _, a := range assignments {
+		ctx := WithTenant(ctx, a.TenantID)
+		key := teamKey{a.TenantID, a.TeamName}
+		team, ok := teams[key]
+		if !ok {
+			team, err = s.teamRepo.GetTeamByName(ctx, a.TeamName)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("get team %s: %w", a.TeamName, err))
+				continue
+			}
+			teams[key] = team
+		}
+
+		sla := team.SLA.WithDefaults(s.defaults)
+		age := now.Sub(a.AssignedAt)
+		switch {
+		case a.EscalatedAt == nil && sla.EscalateAfter > 0 && age >= sla.EscalateAfter:
+			err = s.escalate(ctx, team, a, sla.Escalation, &report)
+		case a.RemindedAt == nil && sla.RemindAfter > 0 && age >= sla.RemindAfter:
+			err = s.remind(ctx, a, &report)
+		default:
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("PR %s reviewer %s: %w", a.PRID, a.UserID, err))
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+func (s *staleReviewService) remind(ctx context.Context, a ReviewAssignment, report *StaleReviewReport) error {
+	reminded := newEvent(ctx, EventReviewerReminded)
+	reminded.PRID = a.PRID
+	reminded.TeamName = a.TeamName
+	reminded.UserID = a.UserID
+
+	// another replica may have reminded of the assignment since it was read
+	marked, err := s.prRepo.MarkReminded(ctx, a.PRID, a.UserID, []Event{reminded})
+	if err != nil {
+		return fmt.Errorf("mark reminded: %w", err)
+	}
+	if marked {
+		report.Reminded++
+	}
+	return nil
+}
+
+func (s *staleReviewService) escalate(ctx context.Context, team *Team, a ReviewAssignment, action EscalationAction, report *StaleReviewReport) error {
+	if action == EscalateReassign {
+		_, err := s.prService.ReassignReviewer(ctx, a.PRID, a.UserID, "")
+		if err == nil {
+			report.Reassigned++
+			return nil
+		}
+		// another replica reassigned the reviewer since the assignment was read
+		if errors.Is(err, ErrReviewerNotAssigned) {
+			return nil
+		}
+		if !errors.Is(err, ErrNoCandidate) {
+			return fmt.Errorf("reassign: %w", err)
+		}
+	}
+
+	escalated := newEvent(ctx, EventReviewerEscalated)
+	escalated.PRID = a.PRID
+	escalated.TeamName = a.TeamName
+	escalated.UserID = teamLead(team, a.UserID)
+	escalated.OldUserID = a.UserID
+
+	marked, err := s.prRepo.MarkEscalated(ctx, a.PRID, a.UserID, []Event{escalated})
+	if err != nil {
+		return fmt.Errorf("mark escalated: %w", err)
+	}
+	if marked {
+		report.Escalated++
+	}
+	return nil
+}
+
+// teamLead returns an active lead of the team other than the reviewer, empty if there is none.
+func teamLead(team *Team, reviewerID string) string {
+	for _, member := range team.Members {
+		if member.Role == RoleLead && member.IsActive && member.ID != reviewerID {
+			return member.ID
+		}
+	}
+	return ""
+}
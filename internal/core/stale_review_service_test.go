@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAssignments serves the same stale assignment to every run like replicas reading it at once.
+type fakeAssignments struct {
+	PullRequestRepository
+
+	mu         sync.Mutex
+	assignment ReviewAssignment
+	reminded   bool
+	events     []Event
+}
+
+func (r *fakeAssignments) GetOpenAssignments(context.Context) ([]ReviewAssignment, error) {
+	return []ReviewAssignment{r.assignment}, nil
+}
+
+func (r *fakeAssignments) MarkReminded(_ context.Context, _, _ string, events []Event) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reminded {
+		return false, nil
+	}
+	r.reminded = true
+	r.events = append(r.events, events...)
+	return true, nil
+}
+
+type fakeTeams struct {
+	TeamRepository
+
+	team *Team
+}
+
+func (r *fakeTeams) GetTeamByName(context.Context, string) (*Team, error) {
+	return r.team, nil
+}
+
+func TestProcessStaleReviews_RemindsOnceAcrossReplicas(t *testing.T) {
+	prs := &fakeAssignments{assignment: ReviewAssignment{
+		PRID:       "pr-1",
+		UserID:     "reviewer",
+		TeamName:   "backend",
+		AssignedAt: time.Now().Add(-3 * time.Hour),
+		TenantID:   DefaultTenant,
+	}}
+	teams := &fakeTeams{team: &Team{Name: "backend"}}
+	sla := ReviewSLA{RemindAfter: time.Hour, EscalateAfter: 24 * time.Hour, Escalation: EscalateToLead}
+
+	var reminded int
+	for range 2 {
+		service := NewStaleReviewService(prs, teams, nil, sla)
+		report, err := service.ProcessStaleReviews(context.Background())
+		if err != nil {
+			t.Fatalf("ProcessStaleReviews: %v", err)
+		}
+		reminded += report.Reminded
+	}
+
+	if reminded != 1 {
+		t.Errorf("reminded %d times, want 1", reminded)
+	}
+	if len(prs.events) != 1 {
+		t.Errorf("stored %d events, want 1", len(prs.events))
+	}
+}
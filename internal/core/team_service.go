@@ -8,17 +8,19 @@ import (
 
 type teamService struct {
 	teamRepo TeamRepository
-	userRepo UserRepository
 }
 
-func NewTeamService(teamRepo TeamRepository, userRepo UserRepository) TeamService {
+func NewTeamService(teamRepo TeamRepository) TeamService {
 	return &teamService{
 		teamRepo: teamRepo,
-		userRepo: userRepo,
 	}
 }
 
 func (s *teamService) CreateTeam(ctx context.Context, team *Team) error {
+	if err := authorize(ctx, AccessAdmin); err != nil {
+		return err
+	}
+
 	t, err := s.teamRepo.GetTeamByName(ctx, team.Name)
 	if t != nil {
 		return ErrTeamExists
@@ -28,17 +30,32 @@ func (s *teamService) CreateTeam(ctx context.Context, team *Team) error {
 		return fmt.Errorf("check team existence: %w", err)
 	}
 
-	if err := s.teamRepo.CreateTeam(ctx, team); err != nil {
-		return fmt.Errorf("create team: %w", err)
+	if team.Policy.RequiredRole == "" {
+		team.Policy.RequiredRole = RoleSenior
 	}
+	if team.Policy.MaxReviewers == 0 {
+		team.Policy.MaxReviewers = DefaultMaxReviewers
+	}
+
+	created := newEvent(ctx, EventTeamCreated)
+	created.TeamName = team.Name
+	events := []Event{created}
 
 	for i := range team.Members {
 		user := &team.Members[i]
 		user.TeamName = team.Name
-
-		if err := s.userRepo.UpsertUser(ctx, user); err != nil {
-			return fmt.Errorf("create user %s: %w", user.ID, err)
+		if user.Role == "" {
+			user.Role = DefaultRole
 		}
+
+		added := created
+		added.Type = EventTeamMemberAdded
+		added.UserID = user.ID
+		events = append(events, added)
+	}
+
+	if err := s.teamRepo.CreateTeam(ctx, team, events); err != nil {
+		return fmt.Errorf("create team: %w", err)
 	}
 
 	return nil
@@ -5,15 +5,20 @@ import (
 	"fmt"
 )
 
+// MaxReviewEventsReplay limits events replayed to a resumed review stream.
+const MaxReviewEventsReplay = 1000
+
 type userService struct {
-	userRepo UserRepository
-	prRepo   PullRequestRepository
+	userRepo  UserRepository
+	prRepo    PullRequestRepository
+	eventRepo EventRepository
 }
 
-func NewUserService(userRepo UserRepository, prRepo PullRequestRepository) UserService {
+func NewUserService(userRepo UserRepository, prRepo PullRequestRepository, eventRepo EventRepository) UserService {
 	return &userService{
-		userRepo: userRepo,
-		prRepo:   prRepo,
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		eventRepo: eventRepo,
 	}
 }
 
@@ -22,16 +27,68 @@ func (s *userService) SetUserActive(ctx context.Context, userID string, isActive
 	if err != nil {
 		return nil, fmt.Errorf("get user: %w", err)
 	}
+	if err := authorizeTeamLead(ctx, s.userRepo, user.TeamName); err != nil {
+		return nil, err
+	}
+
+	if user.IsActive == isActive {
+		return user, nil
+	}
 
 	user.IsActive = isActive
-	if err := s.userRepo.UpsertUser(ctx, user); err != nil {
+	eventType := EventUserDeactivated
+	if isActive {
+		eventType = EventUserActivated
+	}
+	event := newEvent(ctx, eventType)
+	event.TeamName = user.TeamName
+	event.UserID = user.ID
+	if err := s.userRepo.UpsertUser(ctx, user, []Event{event}); err != nil {
 		return nil, fmt.Errorf("upsert user: %w", err)
 	}
 
 	return user, nil
 }
 
+func (s *userService) SetEmailDigest(ctx context.Context, userID string, enabled bool) (*User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if err := authorizeTeamLeadOr(ctx, s.userRepo, user.TeamName, userID); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SetEmailDigest(ctx, userID, enabled); err != nil {
+		return nil, fmt.Errorf("set email digest: %w", err)
+	}
+
+	user.EmailDigest = enabled
+	return user, nil
+}
+
+func (s *userService) GetReviewEvents(ctx context.Context, userID string, afterID int64) ([]Event, error) {
+	if err := authorizeSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if afterID <= 0 {
+		return nil, nil
+	}
+
+	events, err := s.eventRepo.GetReviewEvents(ctx, userID, afterID, MaxReviewEventsReplay)
+	if err != nil {
+		return nil, fmt.Errorf("get review events: %w", err)
+	}
+	return events, nil
+}
+
 func (s *userService) GetUserReviewRequests(ctx context.Context, userID string) ([]*PullRequest, error) {
+	if err := authorizeSelf(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	_, err := s.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("get user: %w", err)
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultDeliveriesLimit = 50
+	MaxDeliveriesLimit     = 500
+)
+
+type webhookService struct {
+	webhookRepo WebhookRepository
+	teamRepo    TeamRepository
+	userRepo    UserRepository
+}
+
+func NewWebhookService(webhookRepo WebhookRepository, teamRepo TeamRepository, userRepo UserRepository) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		teamRepo:    teamRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// RegisterWebhook stores the webhook, generating a signing secret if none is given.
+func (s *webhookService) RegisterWebhook(ctx context.Context, webhook *Webhook) error {
+	if err := authorizeTeamLead(ctx, s.userRepo, webhook.TeamName); err != nil {
+		return err
+	}
+	if _, err := s.teamRepo.GetTeamByName(ctx, webhook.TeamName); err != nil {
+		return fmt.Errorf("get team: %w", err)
+	}
+
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return fmt.Errorf("generate secret: %w", err)
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+	webhook.CreatedAt = time.Now()
+
+	if err := s.webhookRepo.CreateWebhook(ctx, webhook); err != nil {
+		return fmt.Errorf("create webhook: %w", err)
+	}
+	return nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context, teamName string) ([]*Webhook, error) {
+	if err := authorizeTeamLead(ctx, s.userRepo, teamName); err != nil {
+		return nil, err
+	}
+	if _, err := s.teamRepo.GetTeamByName(ctx, teamName); err != nil {
+		return nil, fmt.Errorf("get team: %w", err)
+	}
+
+	webhooks, err := s.webhookRepo.GetWebhooksByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get team webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	if err := s.authorizeWebhook(ctx, webhookID); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.DeleteWebhook(ctx, webhookID); err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	return nil
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*WebhookDelivery, error) {
+	if err := s.authorizeWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultDeliveriesLimit
+	}
+	limit = min(limit, MaxDeliveriesLimit)
+
+	deliveries, err := s.webhookRepo.GetDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// authorizeWebhook permits the callers authorizeTeamLead permits for the team of the webhook.
+func (s *webhookService) authorizeWebhook(ctx context.Context, webhookID int64) error {
+	webhook, err := s.webhookRepo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return fmt.Errorf("get webhook: %w", err)
+	}
+	return authorizeTeamLead(ctx, s.userRepo, webhook.TeamName)
+}
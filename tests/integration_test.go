@@ -1,11 +1,18 @@
 package integration_tests
 
 import (
+	"bufio"
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,22 +23,50 @@ import (
 
 const baseURL = "http://localhost:8080"
 
+// githubSecret matches GITHUB_WEBHOOK_SECRET of the api service in compose.yaml.
+const githubSecret = "github-secret"
+
+// gitlabToken matches GITLAB_WEBHOOK_TOKEN of the api service in compose.yaml.
+const gitlabToken = "gitlab-token"
+
+// giteaSecret matches GITEA_WEBHOOK_SECRET of the api service in compose.yaml.
+const giteaSecret = "gitea-secret"
+
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName string        `json:"team_name"`
+	Policy   *ReviewPolicy `json:"policy,omitempty"`
+	SLA      *ReviewSLA    `json:"sla,omitempty"`
+	Members  []TeamMember  `json:"members"`
+}
+
+type ReviewSLA struct {
+	RemindAfter   string `json:"remind_after,omitempty"`
+	EscalateAfter string `json:"escalate_after,omitempty"`
+	Escalation    string `json:"escalation,omitempty"`
+}
+
+type ReviewPolicy struct {
+	RequiredRole  string `json:"required_role,omitempty"`
+	RequiredCount int    `json:"required_count,omitempty"`
+	MaxReviewers  int    `json:"max_reviewers,omitempty"`
 }
 
 type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role,omitempty"`
+	Email    string `json:"email,omitempty"`
 }
 
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name"`
+	IsActive    bool   `json:"is_active"`
+	Role        string `json:"role"`
+	Email       string `json:"email,omitempty"`
+	EmailDigest bool   `json:"email_digest"`
 }
 
 type PullRequest struct {
@@ -55,9 +90,18 @@ type ErrorResponse struct {
 	Error struct {
 		Code    string `json:"code"`
 		Message string `json:"message"`
+		Details []struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"details"`
 	} `json:"error"`
 }
 
+type ForgeEventResponse struct {
+	Status string       `json:"status"`
+	PR     *PullRequest `json:"pr"`
+}
+
 func makeRequest(t *testing.T, method, path string, body any) (*http.Response, []byte) {
 	var reqBody []byte
 	var err error
@@ -404,3 +448,1087 @@ func TestUsersGetReview(t *testing.T) {
 	require.NoError(t, json.Unmarshal(body, &respStruct))
 	assert.Equal(t, reviewer, respStruct.UserID)
 }
+
+func TestPRCreate_PolicyAssignsSenior(t *testing.T) {
+	teamName := uniqueID("team")
+	author := "author-g"
+	senior := "senior-g"
+	team := Team{
+		TeamName: teamName,
+		Policy:   &ReviewPolicy{RequiredRole: "senior", RequiredCount: 1},
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorG", IsActive: true, Role: "middle"},
+			{UserID: "junior-g1", Username: "JuniorG1", IsActive: true, Role: "junior"},
+			{UserID: "junior-g2", Username: "JuniorG2", IsActive: true, Role: "junior"},
+			{UserID: "junior-g3", Username: "JuniorG3", IsActive: true, Role: "junior"},
+			{UserID: senior, Username: "SeniorG", IsActive: true, Role: "senior"},
+		},
+	}
+	created := createTeam(t, team)
+	require.NotNil(t, created.Policy)
+	assert.Equal(t, "senior", created.Policy.RequiredRole)
+	assert.Equal(t, 1, created.Policy.RequiredCount)
+
+	for i := range 5 {
+		pr := createPR(t, uniqueID("pr"), fmt.Sprintf("policy test %d", i), author, http.StatusCreated)
+		assert.Len(t, pr.AssignedReviewers, 2)
+		assert.Contains(t, pr.AssignedReviewers, senior, "senior must fill the mandatory slot")
+	}
+}
+
+func TestPRReassign_KeepsSeniorPolicy(t *testing.T) {
+	teamName := uniqueID("team")
+	author := "author-h"
+	team := Team{
+		TeamName: teamName,
+		Policy:   &ReviewPolicy{RequiredRole: "senior", RequiredCount: 1},
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorH", IsActive: true},
+			{UserID: "senior-h1", Username: "SeniorH1", IsActive: true, Role: "senior"},
+			{UserID: "lead-h", Username: "LeadH", IsActive: true, Role: "lead"},
+			{UserID: "junior-h1", Username: "JuniorH1", IsActive: true, Role: "junior"},
+			{UserID: "junior-h2", Username: "JuniorH2", IsActive: true, Role: "junior"},
+			{UserID: "junior-h3", Username: "JuniorH3", IsActive: true, Role: "junior"},
+		},
+	}
+	_ = createTeam(t, team)
+
+	seniors := map[string]bool{"senior-h1": true, "lead-h": true}
+	prID := uniqueID("pr")
+	pr := createPR(t, prID, "policy reassign", author, http.StatusCreated)
+
+	var onlySenior string
+	for _, rid := range pr.AssignedReviewers {
+		if seniors[rid] {
+			if onlySenior != "" {
+				t.Skip("both seniors were assigned, the invariant cannot be broken")
+			}
+			onlySenior = rid
+		}
+	}
+	require.NotEmpty(t, onlySenior)
+
+	_, replacedBy := reassignPR(t, prID, onlySenior, http.StatusOK)
+	assert.True(t, seniors[replacedBy], "the only senior must be replaced by a senior, got %s", replacedBy)
+}
+
+func TestPRCreate_RequestedAndExcludedReviewers(t *testing.T) {
+	teamName := uniqueID("team")
+	author := "author-i"
+	team := Team{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorI", IsActive: true},
+			{UserID: "rev-i1", Username: "RevI1", IsActive: true},
+			{UserID: "rev-i2", Username: "RevI2", IsActive: true},
+			{UserID: "rev-i3", Username: "RevI3", IsActive: true},
+			{UserID: "rev-i4", Username: "RevI4", IsActive: false},
+		},
+	}
+	_ = createTeam(t, team)
+
+	req := map[string]any{
+		"pull_request_id":     uniqueID("pr"),
+		"pull_request_name":   "requested reviewers",
+		"author_id":           author,
+		"requested_reviewers": []string{"rev-i1"},
+		"excluded_reviewers":  []string{"rev-i2"},
+	}
+	resp, body := makeRequest(t, "POST", "/pullRequest/create", req)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "createPR body: %s", string(body))
+
+	var r struct {
+		PR PullRequest `json:"pr"`
+	}
+	require.NoError(t, json.Unmarshal(body, &r))
+	assert.ElementsMatch(t, []string{"rev-i1", "rev-i3"}, r.PR.AssignedReviewers)
+
+	cases := []struct {
+		name     string
+		req      map[string]any
+		wantCode string
+	}{
+		{"inactive", map[string]any{"requested_reviewers": []string{"rev-i4"}}, "REVIEWER_INACTIVE"},
+		{"not in team", map[string]any{"requested_reviewers": []string{"stranger-i"}}, "REVIEWER_NOT_IN_TEAM"},
+		{"author", map[string]any{"requested_reviewers": []string{author}}, "REVIEWER_IS_AUTHOR"},
+		{"conflict", map[string]any{"requested_reviewers": []string{"rev-i1"}, "excluded_reviewers": []string{"rev-i1"}}, "REVIEWER_CONFLICT"},
+		{"too many", map[string]any{"requested_reviewers": []string{"rev-i1", "rev-i2", "rev-i3"}}, "TOO_MANY_REVIEWERS"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req["pull_request_id"] = uniqueID("pr")
+			tc.req["pull_request_name"] = tc.name
+			tc.req["author_id"] = author
+			resp, body := makeRequest(t, "POST", "/pullRequest/create", tc.req)
+			assert.Contains(t, []int{http.StatusBadRequest, http.StatusConflict}, resp.StatusCode, "body: %s", string(body))
+
+			var errResp ErrorResponse
+			require.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, tc.wantCode, errResp.Error.Code)
+		})
+	}
+}
+
+func makeActorRequest(t *testing.T, actorID, method, path string, body any) (*http.Response, []byte) {
+	t.Helper()
+	reqBody, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(method, baseURL+path, bytes.NewReader(reqBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Actor-ID", actorID)
+
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	require.NoError(t, err)
+	defer closer.CloseOrPanic(nil, resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, respBody
+}
+
+func changeReviewer(t *testing.T, action, prID, reviewerID, actorID string, expectStatus int) (PullRequest, string) {
+	t.Helper()
+	req := map[string]string{"pull_request_id": prID, "reviewer_id": reviewerID}
+	resp, body := makeActorRequest(t, actorID, "POST", "/pullRequest/reviewers/"+action, req)
+	require.Equal(t, expectStatus, resp.StatusCode, "%s reviewer body: %s", action, string(body))
+
+	if expectStatus == http.StatusOK {
+		var r struct {
+			PR PullRequest `json:"pr"`
+		}
+		require.NoError(t, json.Unmarshal(body, &r))
+		return r.PR, ""
+	}
+
+	var errResp ErrorResponse
+	_ = json.Unmarshal(body, &errResp)
+	return PullRequest{}, errResp.Error.Code
+}
+
+func TestPRReviewers_AddRemove(t *testing.T) {
+	teamName := uniqueID("team")
+	author := "author-j"
+	lead := "lead-j"
+	team := Team{
+		TeamName: teamName,
+		Policy:   &ReviewPolicy{MaxReviewers: 3},
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorJ", IsActive: true},
+			{UserID: lead, Username: "LeadJ", IsActive: true, Role: "lead"},
+			{UserID: "rev-j1", Username: "RevJ1", IsActive: true},
+			{UserID: "rev-j2", Username: "RevJ2", IsActive: true},
+			{UserID: "rev-j3", Username: "RevJ3", IsActive: true},
+			{UserID: "rev-j4", Username: "RevJ4", IsActive: false},
+		},
+	}
+	_ = createTeam(t, team)
+
+	prID := uniqueID("pr")
+	req := map[string]any{
+		"pull_request_id":     prID,
+		"pull_request_name":   "manual reviewers",
+		"author_id":           author,
+		"requested_reviewers": []string{"rev-j1", "rev-j2"},
+	}
+	resp, body := makeRequest(t, "POST", "/pullRequest/create", req)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "createPR body: %s", string(body))
+
+	_, code := changeReviewer(t, "add", prID, "rev-j4", lead, http.StatusConflict)
+	assert.Equal(t, "REVIEWER_INACTIVE", code)
+	_, code = changeReviewer(t, "add", prID, "rev-j1", lead, http.StatusConflict)
+	assert.Equal(t, "ALREADY_ASSIGNED", code)
+
+	pr, _ := changeReviewer(t, "add", prID, "rev-j3", lead, http.StatusOK)
+	assert.ElementsMatch(t, []string{"rev-j1", "rev-j2", "rev-j3"}, pr.AssignedReviewers)
+
+	_, code = changeReviewer(t, "add", prID, lead, lead, http.StatusBadRequest)
+	assert.Equal(t, "TOO_MANY_REVIEWERS", code)
+
+	pr, _ = changeReviewer(t, "remove", prID, "rev-j2", lead, http.StatusOK)
+	assert.ElementsMatch(t, []string{"rev-j1", "rev-j3"}, pr.AssignedReviewers)
+	_, code = changeReviewer(t, "remove", prID, "rev-j2", lead, http.StatusConflict)
+	assert.Equal(t, "NOT_ASSIGNED", code)
+
+	_ = mergePR(t, prID, http.StatusOK)
+	_, code = changeReviewer(t, "remove", prID, "rev-j1", lead, http.StatusConflict)
+	assert.Equal(t, "PR_MERGED", code)
+}
+
+func TestPRReassign_ToChosenReviewer(t *testing.T) {
+	teamName := uniqueID("team")
+	otherTeam := uniqueID("team")
+	author := "author-k"
+	team := Team{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorK", IsActive: true},
+			{UserID: "rev-k1", Username: "RevK1", IsActive: true},
+			{UserID: "rev-k2", Username: "RevK2", IsActive: true},
+			{UserID: "rev-k3", Username: "RevK3", IsActive: true},
+			{UserID: "rev-k4", Username: "RevK4", IsActive: false},
+		},
+	}
+	_ = createTeam(t, team)
+	_ = createTeam(t, Team{
+		TeamName: otherTeam,
+		Members:  []TeamMember{{UserID: "outsider-k", Username: "OutsiderK", IsActive: true}},
+	})
+
+	prID := uniqueID("pr")
+	req := map[string]any{
+		"pull_request_id":     prID,
+		"pull_request_name":   "chosen replacement",
+		"author_id":           author,
+		"requested_reviewers": []string{"rev-k1", "rev-k2"},
+	}
+	resp, body := makeRequest(t, "POST", "/pullRequest/create", req)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "createPR body: %s", string(body))
+
+	cases := []struct {
+		newUserID  string
+		wantStatus int
+		wantCode   string
+	}{
+		{"rev-k4", http.StatusConflict, "REVIEWER_INACTIVE"},
+		{"outsider-k", http.StatusConflict, "REVIEWER_NOT_IN_TEAM"},
+		{"rev-k2", http.StatusConflict, "ALREADY_ASSIGNED"},
+		{author, http.StatusBadRequest, "REVIEWER_IS_AUTHOR"},
+	}
+	for _, tc := range cases {
+		req := map[string]string{"pull_request_id": prID, "old_reviewer_id": "rev-k1", "new_user_id": tc.newUserID}
+		resp, body := makeRequest(t, "POST", "/pullRequest/reassign", req)
+		require.Equal(t, tc.wantStatus, resp.StatusCode, "reassign to %s body: %s", tc.newUserID, string(body))
+
+		var errResp ErrorResponse
+		require.NoError(t, json.Unmarshal(body, &errResp))
+		assert.Equal(t, tc.wantCode, errResp.Error.Code)
+	}
+
+	req2 := map[string]string{"pull_request_id": prID, "old_reviewer_id": "rev-k1", "new_user_id": "rev-k3"}
+	resp, body = makeRequest(t, "POST", "/pullRequest/reassign", req2)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "reassign body: %s", string(body))
+
+	var r struct {
+		PR         PullRequest `json:"pr"`
+		ReplacedBy string      `json:"replaced_by"`
+	}
+	require.NoError(t, json.Unmarshal(body, &r))
+	assert.Equal(t, "rev-k3", r.ReplacedBy)
+	assert.ElementsMatch(t, []string{"rev-k2", "rev-k3"}, r.PR.AssignedReviewers)
+}
+
+type Event struct {
+	ID            int64  `json:"id"`
+	Type          string `json:"type"`
+	PullRequestID string `json:"pull_request_id"`
+	TeamName      string `json:"team_name"`
+	UserID        string `json:"user_id"`
+	OldUserID     string `json:"old_user_id"`
+	ActorID       string `json:"actor_id"`
+	CreatedAt     string `json:"created_at"`
+}
+
+func TestPRHistory_And_Audit(t *testing.T) {
+	teamName := uniqueID("team")
+	author := "author-l"
+	team := Team{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorL", IsActive: true},
+			{UserID: "rev-l1", Username: "RevL1", IsActive: true},
+			{UserID: "rev-l2", Username: "RevL2", IsActive: true},
+			{UserID: "rev-l3", Username: "RevL3", IsActive: true},
+		},
+	}
+	_ = createTeam(t, team)
+
+	prID := uniqueID("pr")
+	req := map[string]any{
+		"pull_request_id":     prID,
+		"pull_request_name":   "history",
+		"author_id":           author,
+		"requested_reviewers": []string{"rev-l1", "rev-l2"},
+	}
+	resp, body := makeRequest(t, "POST", "/pullRequest/create", req)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "createPR body: %s", string(body))
+
+	_, replacedBy := reassignPR(t, prID, "rev-l1", http.StatusOK)
+	require.Equal(t, "rev-l3", replacedBy)
+	_ = mergePR(t, prID, http.StatusOK)
+
+	resp, body = makeRequest(t, "GET", "/pullRequest/history?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "history body: %s", string(body))
+
+	var history struct {
+		PullRequestID string  `json:"pull_request_id"`
+		Events        []Event `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(body, &history))
+	require.Len(t, history.Events, 5)
+	assert.Equal(t, "pr.created", history.Events[0].Type)
+	assert.Equal(t, author, history.Events[0].ActorID)
+	assert.Equal(t, "reviewer.assigned", history.Events[1].Type)
+	assert.Equal(t, "reviewer.assigned", history.Events[2].Type)
+	assert.Equal(t, "reviewer.reassigned", history.Events[3].Type)
+	assert.Equal(t, "rev-l1", history.Events[3].OldUserID)
+	assert.Equal(t, "rev-l3", history.Events[3].UserID)
+	assert.Equal(t, "pr.merged", history.Events[4].Type)
+
+	resp, body = makeRequest(t, "GET", "/audit?type=reviewer.reassigned&team_name="+teamName, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "audit body: %s", string(body))
+
+	var audit struct {
+		Events []Event `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(body, &audit))
+	require.Len(t, audit.Events, 1)
+	assert.Equal(t, prID, audit.Events[0].PullRequestID)
+
+	resp, _ = makeRequest(t, "GET", "/pullRequest/history?pull_request_id="+uniqueID("missing"), nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestWebhooks_RegisterListRemove(t *testing.T) {
+	teamName := uniqueID("team")
+	_ = createTeam(t, Team{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: "author-m", Username: "AuthorM", IsActive: true}},
+	})
+
+	req := map[string]any{
+		"team_name": teamName,
+		"url":       "http://localhost:9999/hook",
+		"events":    []string{"reviewer.assigned", "pr.merged"},
+	}
+	resp, body := makeRequest(t, "POST", "/webhooks/add", req)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "add webhook body: %s", string(body))
+
+	var added struct {
+		Webhook struct {
+			ID     int64    `json:"webhook_id"`
+			Secret string   `json:"secret"`
+			Events []string `json:"events"`
+		} `json:"webhook"`
+	}
+	require.NoError(t, json.Unmarshal(body, &added))
+	assert.NotEmpty(t, added.Webhook.Secret, "secret must be generated")
+	assert.ElementsMatch(t, []string{"reviewer.assigned", "pr.merged"}, added.Webhook.Events)
+
+	resp, body = makeRequest(t, "GET", "/webhooks/list?team_name="+teamName, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "list webhooks body: %s", string(body))
+	var list struct {
+		Webhooks []struct {
+			ID     int64  `json:"webhook_id"`
+			Secret string `json:"secret"`
+		} `json:"webhooks"`
+	}
+	require.NoError(t, json.Unmarshal(body, &list))
+	require.Len(t, list.Webhooks, 1)
+	assert.Equal(t, added.Webhook.ID, list.Webhooks[0].ID)
+	assert.Empty(t, list.Webhooks[0].Secret, "secret must not be listed")
+
+	resp, body = makeRequest(t, "POST", "/webhooks/add", map[string]any{"team_name": teamName, "url": "ftp://example"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "body: %s", string(body))
+
+	resp, _ = makeRequest(t, "POST", "/webhooks/remove", map[string]any{"webhook_id": added.Webhook.ID})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp, _ = makeRequest(t, "POST", "/webhooks/remove", map[string]any{"webhook_id": added.Webhook.ID})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// sendGitHubEvent replays a recorded payload from testdata/github, pointing it
+// at the given repository and author login so that runs do not collide.
+func sendGitHubEvent(t *testing.T, event, fixture, repo, login, secret string) (*http.Response, []byte) {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "github", fixture))
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(raw, &payload))
+	payload["repository"].(map[string]any)["full_name"] = repo
+	payload["pull_request"].(map[string]any)["user"].(map[string]any)["login"] = login
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	req, err := http.NewRequest("POST", baseURL+"/integrations/github/webhook", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	defer closer.CloseOrPanic(nil, resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, respBody
+}
+
+func TestGitHubWebhook_MirrorsPullRequest(t *testing.T) {
+	teamName := uniqueID("team")
+	author := "author-n"
+	_ = createTeam(t, Team{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorN", IsActive: true},
+			{UserID: "rev-n1", Username: "RevN1", IsActive: true},
+			{UserID: "rev-n2", Username: "RevN2", IsActive: true},
+		},
+	})
+	login := uniqueID("octocat")
+	repo := uniqueID("octo-org/octo-repo")
+	prID := "github:" + repo + "#42"
+
+	resp, body := sendGitHubEvent(t, "pull_request", "pull_request_opened.json", repo, login, "wrong-secret")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "body: %s", string(body))
+
+	resp, body = sendGitHubEvent(t, "pull_request", "pull_request_opened.json", repo, login, githubSecret)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "body: %s", string(body))
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &errResp))
+	assert.Equal(t, "IDENTITY_NOT_MAPPED", errResp.Error.Code)
+
+	identity := map[string]string{"forge": "github", "login": login, "user_id": author}
+	resp, body = makeRequest(t, "POST", "/integrations/identities/add", identity)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "link identity body: %s", string(body))
+
+	resp, body = sendGitHubEvent(t, "pull_request", "pull_request_opened.json", repo, login, githubSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "opened body: %s", string(body))
+	var opened ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &opened))
+	require.NotNil(t, opened.PR)
+	assert.Equal(t, prID, opened.PR.PullRequestID)
+	assert.Equal(t, "Add retry to payment client", opened.PR.PullRequestName)
+	assert.Equal(t, author, opened.PR.AuthorID)
+	assert.ElementsMatch(t, []string{"rev-n1", "rev-n2"}, opened.PR.AssignedReviewers)
+
+	// redelivery must not fail nor reassign
+	resp, body = sendGitHubEvent(t, "pull_request", "pull_request_opened.json", repo, login, githubSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "redelivered body: %s", string(body))
+	var redelivered ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &redelivered))
+	assert.Equal(t, opened.PR.AssignedReviewers, redelivered.PR.AssignedReviewers)
+
+	resp, body = sendGitHubEvent(t, "pull_request", "pull_request_closed.json", repo, login, githubSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "closed body: %s", string(body))
+	var closed ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &closed))
+	assert.Equal(t, "ignored", closed.Status)
+
+	resp, body = sendGitHubEvent(t, "pull_request", "pull_request_reopened.json", repo, login, githubSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "reopened body: %s", string(body))
+	var reopened ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &reopened))
+	assert.Equal(t, "OPEN", reopened.PR.Status)
+
+	resp, body = sendGitHubEvent(t, "pull_request", "pull_request_closed_merged.json", repo, login, githubSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "merged body: %s", string(body))
+	var merged ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &merged))
+	assert.Equal(t, "processed", merged.Status)
+	assert.Equal(t, "MERGED", merged.PR.Status)
+
+	resp, body = sendGitHubEvent(t, "issues", "pull_request_opened.json", repo, login, githubSecret)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+}
+
+// sendGitLabEvent replays a recorded payload from testdata/gitlab, pointing it
+// at the given project and username so that runs do not collide.
+func sendGitLabEvent(t *testing.T, fixture, project, username, token string) (*http.Response, []byte) {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "gitlab", fixture))
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(raw, &payload))
+	payload["project"].(map[string]any)["path_with_namespace"] = project
+	payload["user"].(map[string]any)["username"] = username
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", baseURL+"/integrations/gitlab/webhook", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", token)
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	defer closer.CloseOrPanic(nil, resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, respBody
+}
+
+func TestGitLabWebhook_MirrorsMergeRequest(t *testing.T) {
+	teamName := uniqueID("team")
+	author := "author-o"
+	_ = createTeam(t, Team{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorO", IsActive: true},
+			{UserID: "rev-o1", Username: "RevO1", IsActive: true},
+		},
+	})
+	username := uniqueID("jdoe")
+	project := uniqueID("platform/billing")
+	prID := "gitlab:" + project + "!7"
+
+	resp, body := sendGitLabEvent(t, "merge_request_open.json", project, username, "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "body: %s", string(body))
+
+	identity := map[string]string{"forge": "gitlab", "login": username, "user_id": author}
+	resp, body = makeRequest(t, "POST", "/integrations/identities/add", identity)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "link identity body: %s", string(body))
+
+	resp, body = sendGitLabEvent(t, "merge_request_open.json", project, username, gitlabToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "open body: %s", string(body))
+	var opened ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &opened))
+	require.NotNil(t, opened.PR)
+	assert.Equal(t, prID, opened.PR.PullRequestID)
+	assert.Equal(t, "Handle currency rounding in invoices", opened.PR.PullRequestName)
+	assert.Equal(t, author, opened.PR.AuthorID)
+	assert.Equal(t, []string{"rev-o1"}, opened.PR.AssignedReviewers)
+
+	resp, body = sendGitLabEvent(t, "merge_request_close.json", project, username, gitlabToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "close body: %s", string(body))
+	var closed ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &closed))
+	assert.Equal(t, "ignored", closed.Status)
+
+	resp, body = sendGitLabEvent(t, "merge_request_reopen.json", project, username, gitlabToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "reopen body: %s", string(body))
+
+	resp, body = sendGitLabEvent(t, "merge_request_merge.json", project, username, gitlabToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "merge body: %s", string(body))
+	var merged ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &merged))
+	assert.Equal(t, "MERGED", merged.PR.Status)
+}
+
+// sendGiteaEvent replays a recorded payload from testdata/gitea, pointing it
+// at the given repository and author login so that runs do not collide.
+func sendGiteaEvent(t *testing.T, fixture, repo, login, secret string) (*http.Response, []byte) {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "gitea", fixture))
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(raw, &payload))
+	payload["repository"].(map[string]any)["full_name"] = repo
+	payload["pull_request"].(map[string]any)["user"].(map[string]any)["login"] = login
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	req, err := http.NewRequest("POST", baseURL+"/integrations/gitea/webhook", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitea-Event", "pull_request")
+	req.Header.Set("X-Gitea-Signature", hex.EncodeToString(mac.Sum(nil)))
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	defer closer.CloseOrPanic(nil, resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, respBody
+}
+
+func TestGiteaWebhook_MirrorsPullRequest(t *testing.T) {
+	teamName := uniqueID("team")
+	author := uniqueID("author-g")
+	_ = createTeam(t, Team{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "AuthorG", IsActive: true},
+			{UserID: uniqueID("rev-g1"), Username: "RevG1", IsActive: true},
+		},
+	})
+	login := uniqueID("gopher")
+	repo := uniqueID("infra/deploy-tools")
+	prID := "gitea:" + repo + "#12"
+
+	resp, body := sendGiteaEvent(t, "pull_request_opened.json", repo, login, "wrong-secret")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "body: %s", string(body))
+
+	identity := map[string]string{"forge": "gitea", "login": login, "user_id": author}
+	resp, body = makeRequest(t, "POST", "/integrations/identities/add", identity)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "link identity body: %s", string(body))
+
+	resp, body = sendGiteaEvent(t, "pull_request_opened.json", repo, login, giteaSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "opened body: %s", string(body))
+	var opened ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &opened))
+	require.NotNil(t, opened.PR)
+	assert.Equal(t, prID, opened.PR.PullRequestID)
+	assert.Equal(t, "Pin base images by digest", opened.PR.PullRequestName)
+	assert.Equal(t, author, opened.PR.AuthorID)
+
+	resp, body = sendGiteaEvent(t, "pull_request_closed_merged.json", repo, login, giteaSecret)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "merged body: %s", string(body))
+	var merged ForgeEventResponse
+	require.NoError(t, json.Unmarshal(body, &merged))
+	assert.Equal(t, "MERGED", merged.PR.Status)
+}
+
+func TestTeamSetChatWebhook(t *testing.T) {
+	teamName := uniqueID("team")
+	_ = createTeam(t, Team{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: "author-p", Username: "AuthorP", IsActive: true}},
+	})
+
+	req := map[string]string{"team_name": teamName, "url": "https://chat.example.com/hooks/abc"}
+	resp, body := makeRequest(t, "POST", "/team/setChatWebhook", req)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+
+	resp, body = makeRequest(t, "POST", "/team/setChatWebhook", map[string]string{"team_name": teamName, "url": "chat"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "body: %s", string(body))
+
+	resp, body = makeRequest(t, "POST", "/team/setChatWebhook", map[string]string{"team_name": uniqueID("missing"), "url": ""})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "body: %s", string(body))
+
+	resp, body = makeRequest(t, "POST", "/team/setChatWebhook", map[string]string{"team_name": teamName, "url": ""})
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+}
+
+func TestUserSetEmailDigest(t *testing.T) {
+	teamName := uniqueID("team")
+	userID := uniqueID("user-q")
+	_ = createTeam(t, Team{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: userID, Username: "UserQ", IsActive: true, Email: "q@example.com"}},
+	})
+
+	resp, body := makeRequest(t, "POST", "/users/setEmailDigest", map[string]any{"user_id": userID, "enabled": false})
+	require.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+	var r struct {
+		User User `json:"user"`
+	}
+	require.NoError(t, json.Unmarshal(body, &r))
+	assert.Equal(t, "q@example.com", r.User.Email)
+	assert.False(t, r.User.EmailDigest)
+
+	// deactivation keeps the opt-out
+	user := setUserActive(t, userID, false)
+	assert.False(t, user.EmailDigest)
+
+	resp, _ = makeRequest(t, "POST", "/users/setEmailDigest", map[string]any{"user_id": uniqueID("missing"), "enabled": true})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, body = makeRequest(t, "POST", "/team/add", Team{
+		TeamName: uniqueID("team"),
+		Members:  []TeamMember{{UserID: uniqueID("user-q"), Username: "UserQ2", IsActive: true, Email: "not an email"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "body: %s", string(body))
+
+	// a display name would break the SMTP recipient of the digest
+	resp, body = makeRequest(t, "POST", "/team/add", Team{
+		TeamName: uniqueID("team"),
+		Members:  []TeamMember{{UserID: uniqueID("user-q"), Username: "UserQ3", IsActive: true, Email: "User Q <q@example.com>"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "body: %s", string(body))
+}
+
+func TestTeamCreate_ReviewSLA(t *testing.T) {
+	teamName := uniqueID("team")
+	_ = createTeam(t, Team{
+		TeamName: teamName,
+		SLA:      &ReviewSLA{RemindAfter: "24h", EscalateAfter: "72h", Escalation: "lead"},
+		Members:  []TeamMember{{UserID: "author-r", Username: "AuthorR", IsActive: true}},
+	})
+
+	team := getTeam(t, teamName)
+	require.NotNil(t, team.SLA)
+	assert.Equal(t, "24h0m0s", team.SLA.RemindAfter)
+	assert.Equal(t, "72h0m0s", team.SLA.EscalateAfter)
+	assert.Equal(t, "lead", team.SLA.Escalation)
+
+	invalid := []ReviewSLA{
+		{RemindAfter: "soon"},
+		{RemindAfter: "48h", EscalateAfter: "24h"},
+		{Escalation: "ignore"},
+	}
+	for _, sla := range invalid {
+		resp, body := makeRequest(t, "POST", "/team/add", Team{
+			TeamName: uniqueID("team"),
+			SLA:      &sla,
+			Members:  []TeamMember{{UserID: uniqueID("user-r"), Username: "UserR", IsActive: true}},
+		})
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "sla %+v body: %s", sla, string(body))
+	}
+}
+
+// openReviewStream connects to the review stream of the user, the response body is closed with the test.
+func openReviewStream(t *testing.T, userID, lastEventID string) *bufio.Reader {
+	req, err := http.NewRequest("GET", baseURL+"/users/reviewStream?user_id="+userID, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { closer.CloseOrPanic(nil, resp.Body) })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	return bufio.NewReader(resp.Body)
+}
+
+// readServerSentEvent reads the next event from the stream, skipping comments.
+func readServerSentEvent(t *testing.T, stream *bufio.Reader) (id string, event Event) {
+	var data string
+	for {
+		line, err := stream.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "" && data != "":
+			require.NoError(t, json.Unmarshal([]byte(data), &event))
+			return id, event
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestUserReviewStream(t *testing.T) {
+	teamName := uniqueID("team")
+	authorID := uniqueID("author-s")
+	reviewerID := uniqueID("rev-s")
+	_ = createTeam(t, Team{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: authorID, Username: "AuthorS", IsActive: true},
+			{UserID: reviewerID, Username: "ReviewerS", IsActive: true},
+		},
+	})
+
+	stream := openReviewStream(t, reviewerID, "")
+
+	prID := uniqueID("pr-s")
+	_ = createPR(t, prID, "Stream", authorID, http.StatusCreated)
+	assignedID, assigned := readServerSentEvent(t, stream)
+	assert.Equal(t, "reviewer.assigned", assigned.Type)
+	assert.Equal(t, prID, assigned.PullRequestID)
+	assert.Equal(t, reviewerID, assigned.UserID)
+
+	_ = mergePR(t, prID, http.StatusOK)
+	_, merged := readServerSentEvent(t, stream)
+	assert.Equal(t, "pr.merged", merged.Type)
+	assert.Equal(t, prID, merged.PullRequestID)
+
+	// a resumed stream replays what followed the last received event
+	resumed := openReviewStream(t, reviewerID, assignedID)
+	mergedID, replayed := readServerSentEvent(t, resumed)
+	assert.Equal(t, fmt.Sprint(merged.ID), mergedID)
+	assert.Equal(t, "pr.merged", replayed.Type)
+
+	resp, _ := makeRequest(t, "GET", "/users/reviewStream?user_id="+uniqueID("missing"), nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestOpenAPIValidation(t *testing.T) {
+	resp, body := makeRequest(t, "GET", "/openapi.yaml", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "openapi: 3")
+
+	invalid := []struct {
+		name   string
+		method string
+		path   string
+		body   any
+	}{
+		{"unknown field", "POST", "/pullRequest/merge", map[string]any{"pull_request_id": "pr-v", "force": true}},
+		{"wrong type", "POST", "/users/setIsActive", map[string]any{"user_id": "user-v", "is_active": "yes"}},
+		{"missing field", "POST", "/pullRequest/create", map[string]any{"pull_request_id": "pr-v", "author_id": "user-v"}},
+		{"unknown enum value", "POST", "/integrations/identities/add", map[string]any{"forge": "svn", "login": "v", "user_id": "user-v"}},
+		{"wrong query type", "GET", "/webhooks/deliveries?webhook_id=abc", nil},
+		{"missing query", "GET", "/team/get", nil},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body := makeRequest(t, tc.method, tc.path, tc.body)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode, "body: %s", string(body))
+
+			var errResp ErrorResponse
+			require.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, "VALIDATION_ERROR", errResp.Error.Code)
+			assert.NotEmpty(t, errResp.Error.Message)
+		})
+	}
+}
+
+func TestProblemDetails(t *testing.T) {
+	path := "/team/get?team_name=" + uniqueID("missing")
+	cases := []struct {
+		accept      string
+		wantProblem bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/problem+json", true},
+		{"application/json;q=0.5, application/problem+json", true},
+		{"application/problem+json;q=0.5, application/json", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.accept, func(t *testing.T) {
+			req, err := http.NewRequest("GET", baseURL+path, nil)
+			require.NoError(t, err)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+			require.NoError(t, err)
+			defer closer.CloseOrPanic(nil, resp.Body)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusNotFound, resp.StatusCode, "body: %s", string(body))
+
+			if !tc.wantProblem {
+				assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+				var errResp ErrorResponse
+				require.NoError(t, json.Unmarshal(body, &errResp))
+				assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
+				return
+			}
+
+			assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+			var problem struct {
+				Type     string `json:"type"`
+				Title    string `json:"title"`
+				Status   int    `json:"status"`
+				Detail   string `json:"detail"`
+				Instance string `json:"instance"`
+				Code     string `json:"code"`
+			}
+			require.NoError(t, json.Unmarshal(body, &problem))
+			assert.Equal(t, "about:blank", problem.Type)
+			assert.Equal(t, "Not Found", problem.Title)
+			assert.Equal(t, http.StatusNotFound, problem.Status)
+			assert.NotEmpty(t, problem.Detail)
+			assert.Equal(t, "/team/get", problem.Instance)
+			assert.Equal(t, "NOT_FOUND", problem.Code)
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	resp, body := makeRequest(t, "POST", "/team/add", map[string]any{
+		"team_name": uniqueID("team"),
+		"members":   []map[string]any{{"user_id": uniqueID("user"), "username": "U", "is_active": true, "role": "boss"}},
+	})
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode, "body: %s", string(body))
+
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &errResp))
+	assert.Equal(t, "VALIDATION_ERROR", errResp.Error.Code)
+	require.NotEmpty(t, errResp.Error.Details)
+	assert.Equal(t, "members[0].role", errResp.Error.Details[0].Field)
+
+	resp, body = makeRequest(t, "POST", "/pullRequest/create", map[string]any{
+		"pull_request_id":   uniqueID("pr"),
+		"pull_request_name": strings.Repeat("x", 2<<20),
+		"author_id":         uniqueID("user"),
+	})
+	require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode, "body: %s", string(body))
+	require.NoError(t, json.Unmarshal(body, &errResp))
+	assert.Equal(t, "VALIDATION_ERROR", errResp.Error.Code)
+}
+
+func makeIdempotentRequest(t *testing.T, path, key string, body any) (*http.Response, []byte) {
+	t.Helper()
+	reqBody, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", baseURL+path, bytes.NewReader(reqBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	require.NoError(t, err)
+	defer closer.CloseOrPanic(nil, resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, respBody
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	teamName := uniqueID("team")
+	author := uniqueID("author")
+	members := []TeamMember{{UserID: author, Username: "Author", IsActive: true}}
+	for i := range 4 {
+		members = append(members, TeamMember{UserID: uniqueID(fmt.Sprintf("rev%d", i)), Username: "Rev", IsActive: true})
+	}
+	_ = createTeam(t, Team{TeamName: teamName, Members: members})
+
+	prID := uniqueID("pr")
+	create := map[string]any{"pull_request_id": prID, "pull_request_name": "retried", "author_id": author}
+	createKey := uniqueID("key")
+	resp, first := makeIdempotentRequest(t, "/pullRequest/create", createKey, create)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "body: %s", string(first))
+	assert.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+
+	resp, repeated := makeIdempotentRequest(t, "/pullRequest/create", createKey, create)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "body: %s", string(repeated))
+	assert.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+	assert.JSONEq(t, string(first), string(repeated))
+
+	var created struct {
+		PR PullRequest `json:"pr"`
+	}
+	require.NoError(t, json.Unmarshal(first, &created))
+	require.NotEmpty(t, created.PR.AssignedReviewers)
+
+	reassign := map[string]string{"pull_request_id": prID, "old_reviewer_id": created.PR.AssignedReviewers[0]}
+	reassignKey := uniqueID("key")
+	resp, first = makeIdempotentRequest(t, "/pullRequest/reassign", reassignKey, reassign)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(first))
+	resp, repeated = makeIdempotentRequest(t, "/pullRequest/reassign", reassignKey, reassign)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(repeated))
+	assert.JSONEq(t, string(first), string(repeated))
+
+	resp, body := makeRequest(t, "GET", "/pullRequest/history?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "history body: %s", string(body))
+	var history struct {
+		Events []Event `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(body, &history))
+	reassignments := 0
+	for _, e := range history.Events {
+		if e.Type == "reviewer.reassigned" {
+			reassignments++
+		}
+	}
+	assert.Equal(t, 1, reassignments, "a repeated reassign must not swap again")
+
+	create["pull_request_name"] = "another body"
+	resp, body = makeIdempotentRequest(t, "/pullRequest/create", createKey, create)
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "body: %s", string(body))
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &errResp))
+	assert.Equal(t, "IDEMPOTENCY_KEY_REUSED", errResp.Error.Code)
+
+	// keys are scoped to the route
+	resp, body = makeIdempotentRequest(t, "/pullRequest/merge", createKey, map[string]string{"pull_request_id": prID})
+	require.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+}
+
+func makeTenantRequest(t *testing.T, tenantID, method, path string, body any) (*http.Response, []byte) {
+	t.Helper()
+	reqBody, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(method, baseURL+path, bytes.NewReader(reqBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", tenantID)
+
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	require.NoError(t, err)
+	defer closer.CloseOrPanic(nil, resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, respBody
+}
+
+func TestTenantIsolation(t *testing.T) {
+	tenantID := uniqueID("acme")
+	teamName := uniqueID("team")
+	author := uniqueID("author")
+	defaultReviewer := uniqueID("rev")
+	tenantReviewer := uniqueID("rev")
+
+	// the same team, user and PR IDs exist in both tenants
+	_ = createTeam(t, Team{TeamName: teamName, Members: []TeamMember{
+		{UserID: author, Username: "Default Author", IsActive: true},
+		{UserID: defaultReviewer, Username: "Default Reviewer", IsActive: true},
+	}})
+	resp, body := makeTenantRequest(t, tenantID, "POST", "/team/add", Team{TeamName: teamName, Members: []TeamMember{
+		{UserID: author, Username: "Tenant Author", IsActive: true},
+		{UserID: tenantReviewer, Username: "Tenant Reviewer", IsActive: true},
+	}})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "body: %s", string(body))
+
+	resp, body = makeTenantRequest(t, tenantID, "GET", "/team/get?team_name="+teamName, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+	var team Team
+	require.NoError(t, json.Unmarshal(body, &team))
+	require.Len(t, team.Members, 2)
+	for _, member := range team.Members {
+		assert.Contains(t, member.Username, "Tenant")
+	}
+
+	prID := uniqueID("pr")
+	pr := createPR(t, prID, "default tenant", author, http.StatusCreated)
+	assert.Equal(t, []string{defaultReviewer}, pr.AssignedReviewers)
+
+	create := map[string]string{"pull_request_id": prID, "pull_request_name": "other tenant", "author_id": author}
+	resp, body = makeTenantRequest(t, tenantID, "POST", "/pullRequest/create", create)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "body: %s", string(body))
+	var created struct {
+		PR PullRequest `json:"pr"`
+	}
+	require.NoError(t, json.Unmarshal(body, &created))
+	assert.Equal(t, []string{tenantReviewer}, created.PR.AssignedReviewers)
+
+	resp, body = makeTenantRequest(t, tenantID, "GET", "/users/getReview?user_id="+defaultReviewer, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "users of other tenants are not visible, body: %s", string(body))
+
+	resp, body = makeTenantRequest(t, "Not A Tenant", "GET", "/team/get?team_name="+teamName, nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode, "body: %s", string(body))
+}
+
+func TestMetrics(t *testing.T) {
+	resp, body := makeRequest(t, "GET", "/team/get?team_name="+uniqueID("missing"), nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode, "body: %s", string(body))
+
+	resp, body = makeRequest(t, "GET", "/metrics", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+	assert.Contains(t, string(body), `pr_reviews_http_requests_total{method="GET",route="GET /team/get",status="404"}`)
+	assert.Contains(t, string(body), "pr_reviews_db_query_duration_seconds_bucket")
+}
+
+func TestHealthProbes(t *testing.T) {
+	resp, body := makeRequest(t, "GET", "/healthz", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+
+	resp, body = makeRequest(t, "GET", "/readyz", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "body: %s", string(body))
+
+	var readiness struct {
+		Status    string `json:"status"`
+		Draining  bool   `json:"draining"`
+		Migration struct {
+			Version uint `json:"version"`
+			Latest  uint `json:"latest"`
+			Dirty   bool `json:"dirty"`
+		} `json:"migration"`
+	}
+	require.NoError(t, json.Unmarshal(body, &readiness))
+	assert.Equal(t, "ok", readiness.Status)
+	assert.False(t, readiness.Draining)
+	assert.False(t, readiness.Migration.Dirty)
+	assert.Equal(t, readiness.Migration.Latest, readiness.Migration.Version)
+}